@@ -0,0 +1,36 @@
+package toolbox
+
+import (
+	"encoding/base64"
+	"fmt"
+)
+
+type base64Provider struct{}
+
+func (p base64Provider) Get(context Context, arguments ...interface{}) (interface{}, error) {
+	if len(arguments) < 2 {
+		return nil, fmt.Errorf("expected at least 2 arguments (operation, payload) but had: %v", len(arguments))
+	}
+	var encoding = base64.StdEncoding
+	if len(arguments) > 2 && AsString(arguments[2]) == "url" {
+		encoding = base64.URLEncoding
+	}
+	var operation = AsString(arguments[0])
+	switch operation {
+	case "encode":
+		return encoding.EncodeToString([]byte(AsString(arguments[1]))), nil
+	case "decode":
+		decoded, err := encoding.DecodeString(AsString(arguments[1]))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode base64: %v", err)
+		}
+		return string(decoded), nil
+	}
+	return nil, fmt.Errorf("unsupported base64 operation: %v", operation)
+}
+
+//NewBase64Provider returns a provider that base64 encodes or decodes (first argument) payload (second argument), if the third argument is "url", URL safe alphabet is used
+func NewBase64Provider() ValueProvider {
+	var result ValueProvider = &base64Provider{}
+	return result
+}
@@ -0,0 +1,40 @@
+package toolbox_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/toolbox"
+)
+
+func TestNewBase64Provider(t *testing.T) {
+	provider := toolbox.NewBase64Provider()
+	{
+		value, err := provider.Get(nil, "encode", "hello?>")
+		assert.Nil(t, err)
+		assert.Equal(t, "aGVsbG8/Pg==", value)
+		value, err = provider.Get(nil, "decode", value)
+		assert.Nil(t, err)
+		assert.Equal(t, "hello?>", value)
+	}
+	{
+		value, err := provider.Get(nil, "encode", []byte("hello?>"), "url")
+		assert.Nil(t, err)
+		assert.Equal(t, "aGVsbG8_Pg==", value)
+		value, err = provider.Get(nil, "decode", value, "url")
+		assert.Nil(t, err)
+		assert.Equal(t, "hello?>", value)
+	}
+	{
+		_, err := provider.Get(nil, "decode", "aGVsbG8_Pg==")
+		assert.NotNil(t, err)
+	}
+	{
+		_, err := provider.Get(nil, "zip", "abc")
+		assert.NotNil(t, err)
+	}
+	{
+		_, err := provider.Get(nil, "encode")
+		assert.NotNil(t, err)
+	}
+}
@@ -0,0 +1,175 @@
+package toolbox
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var byteSizeUnits = []string{"B", "KB", "MB", "GB", "TB", "PB", "EB"}
+
+//round returns value rounded to the nearest integer, halves are rounded away from zero
+func round(value float64) float64 {
+	if value < 0 {
+		return -math.Floor(-value + 0.5)
+	}
+	return math.Floor(value + 0.5)
+}
+
+//toInt64 converts numeric or numeric text value to int64 without going through platform dependent int
+func toInt64(value interface{}) (int64, error) {
+	reflectValue := reflect.ValueOf(value)
+	switch reflectValue.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return reflectValue.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if reflectValue.Uint() > math.MaxInt64 {
+			return 0, fmt.Errorf("value %v is out of int64 range", value)
+		}
+		return int64(reflectValue.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return int64(reflectValue.Float()), nil
+	}
+	var text = AsString(value)
+	if result, err := strconv.ParseInt(text, 10, 64); err == nil {
+		return result, nil
+	}
+	floatValue, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return 0, err
+	}
+	return int64(floatValue), nil
+}
+
+//FormatByteSize formats size in bytes with one decimal digit precision and the largest unit that keeps value at least 1, i.e. 1.5 KB, 3.2 MB, 4 GB,
+//units are binary (1024) multiples unless si is set, in which case decimal (1000) multiples are used
+func FormatByteSize(size int64, si bool) string {
+	var base = 1024.0
+	if si {
+		base = 1000.0
+	}
+	var sign = ""
+	var value = float64(size)
+	if value < 0 {
+		sign = "-"
+		value = -value
+	}
+	var unit = 0
+	for value >= base && unit+1 < len(byteSizeUnits) {
+		value /= base
+		unit++
+	}
+	if unit == 0 {
+		return fmt.Sprintf("%v%d B", sign, int64(value))
+	}
+	value = round(value*10) / 10
+	if value >= base && unit+1 < len(byteSizeUnits) {
+		value = round(value/base*10) / 10
+		unit++
+	}
+	return sign + strconv.FormatFloat(value, 'f', -1, 64) + " " + byteSizeUnits[unit]
+}
+
+var byteSizeMultipliers = map[string]float64{
+	"":    1,
+	"b":   1,
+	"k":   1e3,
+	"kb":  1e3,
+	"m":   1e6,
+	"mb":  1e6,
+	"g":   1e9,
+	"gb":  1e9,
+	"t":   1e12,
+	"tb":  1e12,
+	"p":   1e15,
+	"pb":  1e15,
+	"e":   1e18,
+	"eb":  1e18,
+	"ki":  1 << 10,
+	"kib": 1 << 10,
+	"mi":  1 << 20,
+	"mib": 1 << 20,
+	"gi":  1 << 30,
+	"gib": 1 << 30,
+	"ti":  1 << 40,
+	"tib": 1 << 40,
+	"pi":  1 << 50,
+	"pib": 1 << 50,
+	"ei":  1 << 60,
+	"eib": 1 << 60,
+}
+
+//ParseByteSize parses byte size text into number of bytes, i.e. "512", "10MB", "1.5 GiB". Suffixes are case insensitive,
+//SI suffixes (KB, MB, GB, TB, PB, EB) use decimal (1000) multiples, binary suffixes (KiB, MiB, GiB, TiB, PiB, EiB) use 1024 multiples,
+//bare numbers and B suffix are bytes. Fractional byte counts are rounded to the nearest byte.
+func ParseByteSize(text string) (int64, error) {
+	var trimmed = strings.TrimSpace(text)
+	var numberEnd = 0
+	for numberEnd < len(trimmed) && (trimmed[numberEnd] == '.' || (trimmed[numberEnd] >= '0' && trimmed[numberEnd] <= '9')) {
+		numberEnd++
+	}
+	if numberEnd == 0 {
+		return 0, fmt.Errorf("invalid byte size: %q, expected number with optional unit suffix", text)
+	}
+	number, err := strconv.ParseFloat(trimmed[:numberEnd], 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid byte size: %q, %v", text, err)
+	}
+	var suffix = strings.TrimSpace(trimmed[numberEnd:])
+	multiplier, ok := byteSizeMultipliers[strings.ToLower(suffix)]
+	if !ok {
+		return 0, fmt.Errorf("invalid byte size: %q, unsupported unit: %v", text, suffix)
+	}
+	var result = round(number * multiplier)
+	if result >= math.MaxInt64 {
+		return 0, fmt.Errorf("invalid byte size: %q, value is out of range", text)
+	}
+	return int64(result), nil
+}
+
+type parseByteSizeProvider struct{}
+
+func (p parseByteSizeProvider) Get(context Context, arguments ...interface{}) (interface{}, error) {
+	if len(arguments) != 1 {
+		return nil, fmt.Errorf("expected 1 argument (size text) but had %v", len(arguments))
+	}
+	return ParseByteSize(AsString(arguments[0]))
+}
+
+//NewParseByteSizeProvider returns a provider that parses byte size text (first argument) into number of bytes (see ParseByteSize)
+func NewParseByteSizeProvider() ValueProvider {
+	var result ValueProvider = &parseByteSizeProvider{}
+	return result
+}
+
+type byteSizeProvider struct{}
+
+func (p byteSizeProvider) Get(context Context, arguments ...interface{}) (interface{}, error) {
+	if len(arguments) == 0 || len(arguments) > 2 {
+		return nil, fmt.Errorf("expected 1 or 2 arguments (size, [si]) but had %v", len(arguments))
+	}
+	size, err := toInt64(arguments[0])
+	if err != nil {
+		return nil, fmt.Errorf("invalid byte size: %v", err)
+	}
+	var si = false
+	if len(arguments) > 1 {
+		switch unitSystem := AsString(arguments[1]); unitSystem {
+		case "si":
+			si = true
+		case "binary":
+		default:
+			return nil, fmt.Errorf("unsupported unit system: %v, expected si or binary", unitSystem)
+		}
+	}
+	return FormatByteSize(size, si), nil
+}
+
+//NewByteSizeProvider returns a provider that formats byte size (first argument) as human readable text (see FormatByteSize),
+//optional second argument "si" switches from binary (1024) to decimal (1000) units
+func NewByteSizeProvider() ValueProvider {
+	var result ValueProvider = &byteSizeProvider{}
+	return result
+}
@@ -0,0 +1,101 @@
+package toolbox_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/toolbox"
+)
+
+func TestNewByteSizeProvider(t *testing.T) {
+	provider := toolbox.NewByteSizeProvider()
+	var useCases = []struct {
+		arguments []interface{}
+		expected  string
+	}{
+		{[]interface{}{0}, "0 B"},
+		{[]interface{}{512}, "512 B"},
+		{[]interface{}{1023}, "1023 B"},
+		{[]interface{}{1024}, "1 KB"},
+		{[]interface{}{1536}, "1.5 KB"},
+		{[]interface{}{"3355443"}, "3.2 MB"},
+		{[]interface{}{int64(4) << 30}, "4 GB"},
+		{[]interface{}{int64(1) << 40}, "1 TB"},
+		{[]interface{}{1048575}, "1 MB"},
+		{[]interface{}{-1536}, "-1.5 KB"},
+		{[]interface{}{-100}, "-100 B"},
+		{[]interface{}{1500, "si"}, "1.5 KB"},
+		{[]interface{}{999, "si"}, "999 B"},
+		{[]interface{}{3200000, "si"}, "3.2 MB"},
+		{[]interface{}{int64(4000000000), "si"}, "4 GB"},
+		{[]interface{}{-2500000, "si"}, "-2.5 MB"},
+		{[]interface{}{1536, "binary"}, "1.5 KB"},
+		{[]interface{}{"5368709120"}, "5 GB"},
+		{[]interface{}{uint64(5) << 30}, "5 GB"},
+		{[]interface{}{"1536.7"}, "1.5 KB"},
+	}
+	for _, useCase := range useCases {
+		value, err := provider.Get(nil, useCase.arguments...)
+		assert.Nil(t, err, useCase.arguments)
+		assert.Equal(t, useCase.expected, value, useCase.arguments)
+	}
+	for _, arguments := range [][]interface{}{
+		{},
+		{"abc"},
+		{1024, "metric"},
+	} {
+		_, err := provider.Get(nil, arguments...)
+		assert.NotNil(t, err, arguments)
+	}
+}
+
+func TestParseByteSize(t *testing.T) {
+	var useCases = []struct {
+		text     string
+		expected int64
+	}{
+		{"512", 512},
+		{" 512 ", 512},
+		{"0", 0},
+		{"100B", 100},
+		{"10KB", 10000},
+		{"10k", 10000},
+		{"10MB", 10000000},
+		{"2 GB", 2000000000},
+		{"1TB", 1000000000000},
+		{"1PB", 1000000000000000},
+		{"1EB", 1000000000000000000},
+		{"1.5 GiB", 1610612736},
+		{"1KiB", 1024},
+		{"2 mib", 2097152},
+		{"1Gi", 1 << 30},
+		{"1TiB", 1 << 40},
+		{"1PiB", 1 << 50},
+		{"1EiB", 1 << 60},
+		{"0.5KB", 500},
+		{"1.0005KB", 1001},
+	}
+	for _, useCase := range useCases {
+		actual, err := toolbox.ParseByteSize(useCase.text)
+		assert.Nil(t, err, useCase.text)
+		assert.Equal(t, useCase.expected, actual, useCase.text)
+	}
+	for _, text := range []string{"", "MB", "abc", "-10MB", "10 XB", "1.2.3KB", "10 MB extra", "16EiB"} {
+		_, err := toolbox.ParseByteSize(text)
+		assert.NotNil(t, err, text)
+	}
+}
+
+func TestNewParseByteSizeProvider(t *testing.T) {
+	provider := toolbox.NewParseByteSizeProvider()
+	value, err := provider.Get(nil, "10MB")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(10000000), value)
+	value, err = provider.Get(nil, 2048)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2048), value)
+	_, err = provider.Get(nil, "10 bytes")
+	assert.NotNil(t, err)
+	_, err = provider.Get(nil)
+	assert.NotNil(t, err)
+}
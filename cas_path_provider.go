@@ -0,0 +1,67 @@
+package toolbox
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+)
+
+type casPathProvider struct{}
+
+func (p casPathProvider) digest(content interface{}) (string, error) {
+	var hash = sha256.New()
+	switch value := content.(type) {
+	case []byte:
+		hash.Write(value)
+	default:
+		var text = AsString(value)
+		if strings.HasPrefix(text, "file:") {
+			reader, _, err := OpenReaderFromURL(text)
+			if err != nil {
+				return "", err
+			}
+			defer reader.Close()
+			if _, err = io.Copy(hash, reader); err != nil {
+				return "", fmt.Errorf("failed to read %v: %v", text, err)
+			}
+		} else {
+			hash.Write([]byte(text))
+		}
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func (p casPathProvider) Get(context Context, arguments ...interface{}) (interface{}, error) {
+	if len(arguments) == 0 {
+		return nil, fmt.Errorf("expected at least one argument but had 0")
+	}
+	var depth, width = 2, 2
+	if len(arguments) > 1 {
+		depth = AsInt(arguments[1])
+	}
+	if len(arguments) > 2 {
+		width = AsInt(arguments[2])
+	}
+	if depth < 0 || width < 1 || depth*width > sha256.Size*2 {
+		return nil, fmt.Errorf("invalid shard depth: %v or width: %v", depth, width)
+	}
+	digest, err := p.digest(arguments[0])
+	if err != nil {
+		return nil, err
+	}
+	var fragments = make([]string, 0)
+	for i := 0; i < depth; i++ {
+		fragments = append(fragments, digest[i*width:(i+1)*width])
+	}
+	fragments = append(fragments, digest)
+	return strings.Join(fragments, "/"), nil
+}
+
+//NewCASPathProvider returns a provider that returns content addressable path derived from SHA-256 of string, bytes or file: URL content (first argument),
+//optional shard depth (default 2) and width (default 2) arguments control path sharding, i.e. ab/cd/abcd...ef
+func NewCASPathProvider() ValueProvider {
+	var result ValueProvider = &casPathProvider{}
+	return result
+}
@@ -0,0 +1,52 @@
+package toolbox_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/toolbox"
+)
+
+func TestNewCASPathProvider(t *testing.T) {
+	provider := toolbox.NewCASPathProvider()
+	const helloDigest = "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+	{
+		value, err := provider.Get(nil, "hello")
+		assert.Nil(t, err)
+		assert.Equal(t, "2c/f2/"+helloDigest, value)
+	}
+	{
+		value, err := provider.Get(nil, []byte("hello"), 3, 1)
+		assert.Nil(t, err)
+		assert.Equal(t, "2/c/f/"+helloDigest, value)
+	}
+	{
+		value, err := provider.Get(nil, "hello", 0)
+		assert.Nil(t, err)
+		assert.Equal(t, helloDigest, value)
+	}
+	{
+		var filename = path.Join(os.TempDir(), "cas_path_provider_test.txt")
+		err := ioutil.WriteFile(filename, []byte("hello"), 0644)
+		assert.Nil(t, err)
+		defer os.Remove(filename)
+		value, err := provider.Get(nil, "file://"+filename)
+		assert.Nil(t, err)
+		assert.Equal(t, "2c/f2/"+helloDigest, value)
+	}
+	{
+		_, err := provider.Get(nil, "file:///non/existing/cas_path_provider_test.txt")
+		assert.NotNil(t, err)
+	}
+	{
+		_, err := provider.Get(nil, "hello", 40, 2)
+		assert.NotNil(t, err)
+	}
+	{
+		_, err := provider.Get(nil)
+		assert.NotNil(t, err)
+	}
+}
@@ -0,0 +1,31 @@
+package toolbox
+
+import (
+	"fmt"
+	"strings"
+)
+
+type caseProvider struct{}
+
+func (p caseProvider) Get(context Context, arguments ...interface{}) (interface{}, error) {
+	if len(arguments) != 2 {
+		return nil, fmt.Errorf("expected 2 arguments (mode, text) but had %v", len(arguments))
+	}
+	var text = AsString(arguments[1])
+	switch mode := AsString(arguments[0]); mode {
+	case "lower":
+		return strings.ToLower(text), nil
+	case "upper":
+		return strings.ToUpper(text), nil
+	case "title":
+		return strings.Title(text), nil
+	default:
+		return nil, fmt.Errorf("unsupported case mode: %v, expected lower, upper or title", mode)
+	}
+}
+
+//NewCaseProvider returns a provider that transforms text (second argument) with mode (first argument): lower, upper or title (strings.Title semantics, first letter of each word is upper cased, remaining letters are left as is)
+func NewCaseProvider() ValueProvider {
+	var result ValueProvider = &caseProvider{}
+	return result
+}
@@ -0,0 +1,39 @@
+package toolbox_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/toolbox"
+)
+
+func TestNewCaseProvider(t *testing.T) {
+	provider := toolbox.NewCaseProvider()
+	var useCases = []struct {
+		arguments []interface{}
+		expected  string
+	}{
+		{[]interface{}{"lower", "Hello World"}, "hello world"},
+		{[]interface{}{"upper", "Hello World"}, "HELLO WORLD"},
+		{[]interface{}{"title", "hello wide world"}, "Hello Wide World"},
+		{[]interface{}{"title", "hello mIXed"}, "Hello MIXed"},
+		{[]interface{}{"lower", "ÉCOLE Ωμέγα"}, "école ωμέγα"},
+		{[]interface{}{"upper", "école ωμέγα привет"}, "ÉCOLE ΩΜΈΓΑ ПРИВЕТ"},
+		{[]interface{}{"title", "élan ñandú привет"}, "Élan Ñandú Привет"},
+		{[]interface{}{"upper", 12}, "12"},
+		{[]interface{}{"lower", ""}, ""},
+	}
+	for _, useCase := range useCases {
+		value, err := provider.Get(nil, useCase.arguments...)
+		assert.Nil(t, err)
+		assert.Equal(t, useCase.expected, value)
+	}
+	{
+		_, err := provider.Get(nil, "camel", "abc")
+		assert.NotNil(t, err)
+	}
+	{
+		_, err := provider.Get(nil, "lower")
+		assert.NotNil(t, err)
+	}
+}
@@ -0,0 +1,38 @@
+package toolbox
+
+import (
+	"math/rand"
+	"time"
+)
+
+//RandomSeed represents a context value that seeds random value providers for reproducible results, i.e. context.Put(RandomSeed(0), RandomSeed(42))
+type RandomSeed int64
+
+//newRandom returns random generator seeded with context RandomSeed or current time if seed is not defined
+func newRandom(context Context) *rand.Rand {
+	var seed = time.Now().UnixNano()
+	if value, ok := GetValue(context, RandomSeed(0)); ok {
+		seed = int64(value.(RandomSeed))
+	}
+	return rand.New(rand.NewSource(seed))
+}
+
+type choiceProvider struct{}
+
+func (p choiceProvider) Get(context Context, arguments ...interface{}) (interface{}, error) {
+	var candidates = arguments
+	if len(arguments) == 1 && arguments[0] != nil && IsSlice(arguments[0]) {
+		candidates = AsSlice(arguments[0])
+	}
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+	return candidates[newRandom(context).Intn(len(candidates))], nil
+}
+
+//NewChoiceProvider returns a provider that returns uniformly random chosen argument, or element of a slice if it is the only argument,
+//nil is returned if there is nothing to choose from. RandomSeed context value makes choice deterministic.
+func NewChoiceProvider() ValueProvider {
+	var result ValueProvider = &choiceProvider{}
+	return result
+}
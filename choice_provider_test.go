@@ -0,0 +1,45 @@
+package toolbox_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/toolbox"
+)
+
+func TestNewChoiceProvider(t *testing.T) {
+	provider := toolbox.NewChoiceProvider()
+	context := toolbox.NewContext()
+	assert.Nil(t, context.Put(toolbox.RandomSeed(0), toolbox.RandomSeed(42)))
+	var choices = []interface{}{"a", "b", "c", "d", "e"}
+	var expected = choices[rand.New(rand.NewSource(42)).Intn(len(choices))]
+	{
+		value, err := provider.Get(context, choices...)
+		assert.Nil(t, err)
+		assert.Equal(t, expected, value)
+		repeated, err := provider.Get(context, choices...)
+		assert.Nil(t, err)
+		assert.Equal(t, value, repeated)
+	}
+	{
+		value, err := provider.Get(context, []string{"a", "b", "c", "d", "e"})
+		assert.Nil(t, err)
+		assert.Equal(t, expected, value)
+	}
+	{
+		value, err := provider.Get(nil, choices...)
+		assert.Nil(t, err)
+		assert.Contains(t, choices, value)
+	}
+	{
+		value, err := provider.Get(nil, "single")
+		assert.Nil(t, err)
+		assert.Equal(t, "single", value)
+	}
+	for _, arguments := range [][]interface{}{{}, {[]int{}}} {
+		value, err := provider.Get(nil, arguments...)
+		assert.Nil(t, err)
+		assert.Nil(t, value)
+	}
+}
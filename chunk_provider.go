@@ -0,0 +1,40 @@
+package toolbox
+
+import (
+	"fmt"
+	"reflect"
+)
+
+type chunkProvider struct{}
+
+func (p chunkProvider) Get(context Context, arguments ...interface{}) (interface{}, error) {
+	if len(arguments) != 2 {
+		return nil, fmt.Errorf("expected 2 arguments (slice, size) but had: %v", len(arguments))
+	}
+	if arguments[0] == nil || !IsSlice(arguments[0]) {
+		return nil, fmt.Errorf("expected slice but had %T", arguments[0])
+	}
+	size, err := ToInt(arguments[1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid chunk size %v: %v", arguments[1], err)
+	}
+	if size <= 0 {
+		return nil, fmt.Errorf("invalid chunk size: %v, expected positive number", size)
+	}
+	sliceValue := DiscoverValueByKind(arguments[0], reflect.Slice)
+	var result = make([]interface{}, 0)
+	for i := 0; i < sliceValue.Len(); i += size {
+		var end = i + size
+		if end > sliceValue.Len() {
+			end = sliceValue.Len()
+		}
+		result = append(result, sliceValue.Slice3(i, end, end).Interface())
+	}
+	return result, nil
+}
+
+//NewChunkProvider returns a provider that splits slice (first argument) into slices of passed in size (second argument), the last chunk can be shorter.
+func NewChunkProvider() ValueProvider {
+	var result ValueProvider = &chunkProvider{}
+	return result
+}
@@ -0,0 +1,39 @@
+package toolbox_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/toolbox"
+)
+
+func TestNewChunkProvider(t *testing.T) {
+	provider := toolbox.NewChunkProvider()
+	{
+		value, err := provider.Get(nil, []interface{}{1, "2", 3, 4, 5}, 2)
+		assert.Nil(t, err)
+		assert.EqualValues(t, []interface{}{[]interface{}{1, "2"}, []interface{}{3, 4}, []interface{}{5}}, value)
+	}
+	{
+		value, err := provider.Get(nil, []string{"a", "b", "c"}, "3")
+		assert.Nil(t, err)
+		assert.EqualValues(t, []interface{}{[]string{"a", "b", "c"}}, value)
+	}
+	{
+		value, err := provider.Get(nil, &[]int{}, 3)
+		assert.Nil(t, err)
+		assert.EqualValues(t, []interface{}{}, value)
+	}
+	{
+		_, err := provider.Get(nil, []int{1, 2}, 0)
+		assert.NotNil(t, err)
+	}
+	{
+		_, err := provider.Get(nil, "abc", 1)
+		assert.NotNil(t, err)
+	}
+	{
+		_, err := provider.Get(nil, []int{1})
+		assert.NotNil(t, err)
+	}
+}
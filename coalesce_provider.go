@@ -0,0 +1,23 @@
+package toolbox
+
+type coalesceProvider struct{}
+
+func (p coalesceProvider) Get(context Context, arguments ...interface{}) (interface{}, error) {
+	for _, argument := range arguments {
+		if argument == nil {
+			continue
+		}
+		if text, ok := argument.(string); ok && text == "" {
+			continue
+		}
+		return argument, nil
+	}
+	return nil, nil
+}
+
+//NewCoalesceProvider returns a provider that returns the first argument that is neither nil nor an empty string, or nil if there is no such argument.
+//Only nil and "" count as empty: zero numbers, false, empty slices and maps are returned as is.
+func NewCoalesceProvider() ValueProvider {
+	var result ValueProvider = &coalesceProvider{}
+	return result
+}
@@ -0,0 +1,31 @@
+package toolbox_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/toolbox"
+)
+
+func TestNewCoalesceProvider(t *testing.T) {
+	provider := toolbox.NewCoalesceProvider()
+	var useCases = []struct {
+		description string
+		arguments   []interface{}
+		expected    interface{}
+	}{
+		{"first value", []interface{}{"abc", "xyz"}, "abc"},
+		{"skip nil and empty", []interface{}{nil, "", "xyz", "abc"}, "xyz"},
+		{"zero int is not empty", []interface{}{nil, "", 0, 1}, 0},
+		{"false is not empty", []interface{}{"", false}, false},
+		{"empty slice is not empty", []interface{}{nil, []int{}}, []int{}},
+		{"whitespace is not empty", []interface{}{"", " "}, " "},
+		{"all empty", []interface{}{nil, "", nil}, nil},
+		{"no arguments", []interface{}{}, nil},
+	}
+	for _, useCase := range useCases {
+		value, err := provider.Get(nil, useCase.arguments...)
+		assert.Nil(t, err, useCase.description)
+		assert.Equal(t, useCase.expected, value, useCase.description)
+	}
+}
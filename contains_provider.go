@@ -0,0 +1,68 @@
+package toolbox
+
+import (
+	"fmt"
+	"strings"
+)
+
+type containsProvider struct{}
+
+func (p containsProvider) Get(context Context, arguments ...interface{}) (interface{}, error) {
+	if len(arguments) < 2 {
+		return nil, fmt.Errorf("expected at least 2 arguments (operation, slice) but had: %v", len(arguments))
+	}
+	var operation = strings.ToLower(AsString(arguments[0]))
+	var elements = make([]string, 0)
+	if arguments[1] != nil {
+		if !IsSlice(arguments[1]) {
+			return nil, fmt.Errorf("expected slice but had %T", arguments[1])
+		}
+		ProcessSlice(arguments[1], func(item interface{}) bool {
+			elements = append(elements, AsString(item))
+			return true
+		})
+	}
+	var candidates = make([]string, 0)
+	var candidateArguments = arguments[2:]
+	if len(candidateArguments) == 1 && candidateArguments[0] != nil && IsSlice(candidateArguments[0]) {
+		candidateArguments = AsSlice(candidateArguments[0])
+	}
+	for _, candidate := range candidateArguments {
+		candidates = append(candidates, AsString(candidate))
+	}
+	var indexOf = func(candidate string) int {
+		for i, element := range elements {
+			if element == candidate {
+				return i
+			}
+		}
+		return -1
+	}
+	var matched = 0
+	for _, candidate := range candidates {
+		if indexOf(candidate) != -1 {
+			matched++
+		}
+	}
+	switch operation {
+	case "any":
+		return matched > 0, nil
+	case "all":
+		return matched == len(candidates), nil
+	case "none":
+		return matched == 0, nil
+	case "indexof":
+		if len(candidates) != 1 {
+			return nil, fmt.Errorf("expected one candidate for indexOf but had: %v", len(candidates))
+		}
+		return indexOf(candidates[0]), nil
+	}
+	return nil, fmt.Errorf("unsupported contains operation: %v", operation)
+}
+
+//NewContainsProvider returns a provider that checks if slice (second argument) contains any, all or none (first argument) of candidates (remaining arguments or a slice),
+//indexOf operation returns position of a candidate or -1. Elements are compared as strings.
+func NewContainsProvider() ValueProvider {
+	var result ValueProvider = &containsProvider{}
+	return result
+}
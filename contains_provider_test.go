@@ -0,0 +1,51 @@
+package toolbox_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/toolbox"
+)
+
+func TestNewContainsProvider(t *testing.T) {
+	provider := toolbox.NewContainsProvider()
+	var slice = []interface{}{1, "b", 3.5}
+
+	var useCases = []struct {
+		description string
+		arguments   []interface{}
+		expected    interface{}
+	}{
+		{"any matched", []interface{}{"any", slice, "x", "1"}, true},
+		{"any not matched", []interface{}{"any", slice, "x", "y"}, false},
+		{"all matched", []interface{}{"all", slice, []string{"b", "3.5"}}, true},
+		{"all not matched", []interface{}{"all", slice, "b", "c"}, false},
+		{"none matched", []interface{}{"none", []string{"a", "b"}, "c"}, true},
+		{"none not matched", []interface{}{"none", []string{"a", "b"}, "a"}, false},
+		{"indexOf", []interface{}{"indexOf", slice, "b"}, 1},
+		{"indexOf missing", []interface{}{"indexOf", slice, "c"}, -1},
+		{"any nil slice", []interface{}{"any", nil, "a"}, false},
+		{"all nil slice", []interface{}{"all", nil, "a"}, false},
+		{"all empty candidates", []interface{}{"all", []int{}}, true},
+		{"none empty slice", []interface{}{"none", []int{}, 1}, true},
+		{"indexOf nil slice", []interface{}{"indexOf", nil, 1}, -1},
+	}
+	for _, useCase := range useCases {
+		value, err := provider.Get(nil, useCase.arguments...)
+		if assert.Nil(t, err, useCase.description) {
+			assert.Equal(t, useCase.expected, value, useCase.description)
+		}
+	}
+	{
+		_, err := provider.Get(nil, "indexOf", slice, 1, 2)
+		assert.NotNil(t, err)
+	}
+	{
+		_, err := provider.Get(nil, "some", slice, 1)
+		assert.NotNil(t, err)
+	}
+	{
+		_, err := provider.Get(nil, "any", "abc", 1)
+		assert.NotNil(t, err)
+	}
+}
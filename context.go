@@ -138,3 +138,41 @@ func NewContext() Context {
 	var result Context = &contextImpl{context: make(map[string]interface{})}
 	return result
 }
+
+//GetValue returns context value for a target type and true if value was found
+func GetValue(context Context, targetType interface{}) (interface{}, bool) {
+	if context == nil || !context.Contains(targetType) {
+		return nil, false
+	}
+	return context.GetOptional(targetType), true
+}
+
+//GetString returns string context value for a target type, or empty string and false if value was not found or is not a string
+func GetString(context Context, targetType interface{}) (string, bool) {
+	value, ok := GetValue(context, targetType)
+	if !ok {
+		return "", false
+	}
+	result, ok := value.(string)
+	return result, ok
+}
+
+//GetInt returns int context value for a target type, or zero and false if value was not found or is not an int
+func GetInt(context Context, targetType interface{}) (int, bool) {
+	value, ok := GetValue(context, targetType)
+	if !ok {
+		return 0, false
+	}
+	result, ok := value.(int)
+	return result, ok
+}
+
+//GetBool returns bool context value for a target type, or false and false if value was not found or is not a bool
+func GetBool(context Context, targetType interface{}) (bool, bool) {
+	value, ok := GetValue(context, targetType)
+	if !ok {
+		return false, false
+	}
+	result, ok := value.(bool)
+	return result, ok
+}
@@ -71,3 +71,53 @@ func TestContext(t *testing.T) {
 	assert.NotNil(t, err)
 
 }
+
+type contextName string
+
+func TestGetString(t *testing.T) {
+	context := toolbox.NewContext()
+	_, ok := toolbox.GetString(context, "")
+	assert.False(t, ok)
+
+	assert.Nil(t, context.Put("", "abc"))
+	value, ok := toolbox.GetString(context, "")
+	assert.True(t, ok)
+	assert.Equal(t, "abc", value)
+
+	//stored type does not match
+	assert.Nil(t, context.Put(contextName(""), contextName("xyz")))
+	value, ok = toolbox.GetString(context, contextName(""))
+	assert.False(t, ok)
+	assert.Equal(t, "", value)
+
+	value, ok = toolbox.GetString(nil, "")
+	assert.False(t, ok)
+}
+
+func TestGetInt(t *testing.T) {
+	context := toolbox.NewContext()
+	assert.Nil(t, context.Put(0, 12))
+	value, ok := toolbox.GetInt(context, 0)
+	assert.True(t, ok)
+	assert.Equal(t, 12, value)
+
+	assert.Nil(t, context.Put(int64(0), int64(3)))
+	value, ok = toolbox.GetInt(context, int64(0))
+	assert.False(t, ok)
+	assert.Equal(t, 0, value)
+}
+
+func TestGetBool(t *testing.T) {
+	context := toolbox.NewContext()
+	_, ok := toolbox.GetBool(context, false)
+	assert.False(t, ok)
+	assert.Nil(t, context.Put(false, true))
+	value, ok := toolbox.GetBool(context, false)
+	assert.True(t, ok)
+	assert.True(t, value)
+
+	assert.Nil(t, context.Put(&Message{}, &Message{message: "abc"}))
+	value, ok = toolbox.GetBool(context, &Message{})
+	assert.False(t, ok)
+	assert.False(t, value)
+}
@@ -2,6 +2,7 @@ package toolbox
 
 import (
 	"fmt"
+	"math"
 	"reflect"
 	"strconv"
 	"strings"
@@ -88,16 +89,48 @@ func ToFloat(value interface{}) (float64, error) {
 
 //AsBoolean converts an input to bool.
 func AsBoolean(value interface{}) bool {
-	if boolValue, ok := value.(bool); ok {
-		return boolValue
-	}
-	valueAsString := AsString(value)
-	if result, err := strconv.ParseBool(valueAsString); err == nil {
+	if result, err := ToBoolean(value); err == nil {
 		return result
 	}
 	return false
 }
 
+//ToBoolean converts an input to bool or error, nil *bool is an error
+func ToBoolean(value interface{}) (bool, error) {
+	switch actualValue := value.(type) {
+	case bool:
+		return actualValue, nil
+	case *bool:
+		if actualValue == nil {
+			return false, fmt.Errorf("invalid boolean value: nil pointer")
+		}
+		return *actualValue, nil
+	}
+	valueAsString := AsString(value)
+	return strconv.ParseBool(valueAsString)
+}
+
+//ToBooleanStrict converts an input to bool, it accepts only bool values and true/false, 1/0, yes/no or on/off tokens (case insensitive), anything else is an error
+func ToBooleanStrict(value interface{}) (bool, error) {
+	switch actualValue := value.(type) {
+	case bool:
+		return actualValue, nil
+	case *bool:
+		if actualValue != nil {
+			return *actualValue, nil
+		}
+	}
+	if value != nil {
+		switch strings.ToLower(AsString(value)) {
+		case "true", "1", "yes", "on":
+			return true, nil
+		case "false", "0", "no", "off":
+			return false, nil
+		}
+	}
+	return false, fmt.Errorf("invalid boolean value: %v, expected true/false, 1/0, yes/no or on/off", value)
+}
+
 //CanConvertToInt returns true if an input can be converted to int value.
 func CanConvertToInt(value interface{}) bool {
 	if _, ok := value.(int); ok {
@@ -203,6 +236,103 @@ func AsTime(value interface{}, dateLayout string) *time.Time {
 	return &timeValue
 }
 
+//DefaultTimeLayouts represents go time layouts tried by AsTimeWithLayouts once supplied layouts failed
+var DefaultTimeLayouts = []string{time.RFC3339Nano, time.RFC3339, time.RFC1123Z, time.RFC1123}
+
+//epochToTime converts numeric epoch to time, unit (seconds, milliseconds, microseconds or nanoseconds) is based on magnitude
+func epochToTime(epoch float64) time.Time {
+	var magnitude = math.Abs(epoch)
+	switch {
+	case magnitude < 1e11:
+		seconds, fraction := math.Modf(epoch)
+		return time.Unix(int64(seconds), int64(fraction*float64(time.Second)))
+	case magnitude < 1e14:
+		var millis = int64(epoch)
+		return time.Unix(millis/1000, (millis%1000)*int64(time.Millisecond))
+	case magnitude < 1e17:
+		var micros = int64(epoch)
+		return time.Unix(micros/1000000, (micros%1000000)*int64(time.Microsecond))
+	}
+	return time.Unix(0, int64(epoch))
+}
+
+//intEpochToTime converts integer epoch to time with the same unit inference as epochToTime, without float precision loss
+func intEpochToTime(epoch int64) time.Time {
+	var magnitude = epoch
+	if magnitude < 0 {
+		magnitude = -magnitude
+	}
+	switch {
+	case magnitude < 0: //math.MinInt64
+	case magnitude < 1e11:
+		return time.Unix(epoch, 0)
+	case magnitude < 1e14:
+		return time.Unix(epoch/1000, (epoch%1000)*int64(time.Millisecond))
+	case magnitude < 1e17:
+		return time.Unix(epoch/1000000, (epoch%1000000)*int64(time.Microsecond))
+	}
+	return time.Unix(0, epoch)
+}
+
+//numericEpochToTime converts int or float value to time, it returns nil for non finite float
+func numericEpochToTime(value interface{}) *time.Time {
+	var timeValue time.Time
+	reflectValue := reflect.ValueOf(value)
+	switch reflectValue.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		timeValue = intEpochToTime(reflectValue.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if reflectValue.Uint() > math.MaxInt64 {
+			return nil
+		}
+		timeValue = intEpochToTime(int64(reflectValue.Uint()))
+	default:
+		var epoch = reflectValue.Float()
+		if math.IsNaN(epoch) || math.IsInf(epoch, 0) {
+			return nil
+		}
+		timeValue = epochToTime(epoch)
+	}
+	return &timeValue
+}
+
+//AsTimeWithLayouts converts an input to time, numeric input is treated as epoch with unit inferred from its magnitude, text input is parsed
+//with passed in java style date formats, numeric text not matching any of them is treated as epoch, otherwise DefaultTimeLayouts
+//and DefaultDateLayout are tried, it returns nil if all attempts failed.
+func AsTimeWithLayouts(value interface{}, layouts ...string) *time.Time {
+	switch actual := value.(type) {
+	case time.Time:
+		return &actual
+	case *time.Time:
+		return actual
+	}
+	if IsInt(value) || IsFloat(value) {
+		return numericEpochToTime(value)
+	}
+	var text = AsString(value)
+	if len(layouts) > 0 {
+		if timeValue, err := ParseTimeWithLayouts(text, layouts...); err == nil {
+			return &timeValue
+		}
+	}
+	if epoch, err := strconv.ParseInt(text, 10, 64); err == nil {
+		timeValue := intEpochToTime(epoch)
+		return &timeValue
+	}
+	if epoch, err := strconv.ParseFloat(text, 64); err == nil {
+		return numericEpochToTime(epoch)
+	}
+	for _, layout := range DefaultTimeLayouts {
+		if timeValue, err := time.Parse(layout, text); err == nil {
+			return &timeValue
+		}
+	}
+	if timeValue, err := ParseTime(text, DefaultDateLayout); err == nil {
+		return &timeValue
+	}
+	return nil
+}
+
 //DiscoverValueAndKind discovers input kind, it applies checks of the following types:  int, float, bool, string
 func DiscoverValueAndKind(input string) (interface{}, reflect.Kind) {
 	if len(input) == 0 {
@@ -299,6 +429,31 @@ func ParseTime(input, layout string) (time.Time, error) {
 	return time.Parse(layout, input)
 }
 
+//ParseTimeInLocation parses time with java style date format, input without zone offset is interpreted in named location (i.e. America/New_York)
+func ParseTimeInLocation(value, dateFormat, location string) (time.Time, error) {
+	timeLocation, err := time.LoadLocation(location)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to load location %v: %v", location, err)
+	}
+	return time.ParseInLocation(DateFormatToLayout(dateFormat), value, timeLocation)
+}
+
+//ParseTimeWithLayouts parses time trying each passed in java style date format in order, it returns the first successful parse or error listing all attempts
+func ParseTimeWithLayouts(value string, layouts ...string) (time.Time, error) {
+	if len(layouts) == 0 {
+		return time.Time{}, fmt.Errorf("failed to parse time: '%v', no layouts were given", value)
+	}
+	var attempts = make([]string, 0, len(layouts))
+	for _, dateFormat := range layouts {
+		result, err := ParseTime(value, DateFormatToLayout(dateFormat))
+		if err == nil {
+			return result, nil
+		}
+		attempts = append(attempts, fmt.Sprintf("%v: %v", dateFormat, err))
+	}
+	return time.Time{}, fmt.Errorf("failed to parse time: '%v', attempts:\n\t%v", value, strings.Join(attempts, "\n\t"))
+}
+
 //Converter represets data converter, it converts incompatibe data structure, like map and struct, string and time, *string to string, etc.
 type Converter struct {
 	DataLayout   string
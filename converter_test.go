@@ -1,8 +1,10 @@
 package toolbox_test
 
 import (
+	"fmt"
 	"github.com/stretchr/testify/assert"
 	"github.com/viant/toolbox"
+	"math"
 	"reflect"
 	"testing"
 	"time"
@@ -451,6 +453,99 @@ func TestAsInt(t *testing.T) {
 	assert.Equal(t, 0, toolbox.AsInt("avc"))
 }
 
+func TestToInt(t *testing.T) {
+	var intValue = 3
+	var valid = []interface{}{3, int8(3), int64(3), uint32(3), 3.7, float32(3.2), &intValue, "3", "3.9"}
+	for _, value := range valid {
+		result, err := toolbox.ToInt(value)
+		assert.Nil(t, err, fmt.Sprintf("%v", value))
+		assert.Equal(t, 3, result, fmt.Sprintf("%v", value))
+	}
+	for _, value := range []interface{}{"abc", "", true, nil, []int{1}} {
+		_, err := toolbox.ToInt(value)
+		assert.NotNil(t, err, fmt.Sprintf("%v", value))
+		assert.Equal(t, 0, toolbox.AsInt(value))
+	}
+}
+
+func TestToFloat(t *testing.T) {
+	var floatValue = 2.5
+	var valid = []interface{}{2.5, float32(2.5), &floatValue, "2.5", "25e-1"}
+	for _, value := range valid {
+		result, err := toolbox.ToFloat(value)
+		assert.Nil(t, err, fmt.Sprintf("%v", value))
+		assert.Equal(t, 2.5, result, fmt.Sprintf("%v", value))
+	}
+	result, err := toolbox.ToFloat(7)
+	assert.Nil(t, err)
+	assert.Equal(t, 7.0, result)
+	for _, value := range []interface{}{"abc", "", false, nil} {
+		_, err := toolbox.ToFloat(value)
+		assert.NotNil(t, err, fmt.Sprintf("%v", value))
+		assert.Equal(t, 0.0, toolbox.AsFloat(value))
+	}
+}
+
+func TestToBoolean(t *testing.T) {
+	var boolValue = true
+	var useCases = map[interface{}]bool{
+		true:    true,
+		false:   false,
+		"true":  true,
+		"FALSE": false,
+		"1":     true,
+		0:       false,
+		1:       true,
+	}
+	for value, expected := range useCases {
+		result, err := toolbox.ToBoolean(value)
+		assert.Nil(t, err, fmt.Sprintf("%v", value))
+		assert.Equal(t, expected, result, fmt.Sprintf("%v", value))
+	}
+	result, err := toolbox.ToBoolean(&boolValue)
+	assert.Nil(t, err)
+	assert.True(t, result)
+	result, err = toolbox.ToBoolean((*bool)(nil))
+	assert.NotNil(t, err)
+	assert.False(t, result)
+	assert.False(t, toolbox.AsBoolean((*bool)(nil)))
+	for _, value := range []interface{}{"yes", "", 1.1, 2, nil} {
+		_, err := toolbox.ToBoolean(value)
+		assert.NotNil(t, err, fmt.Sprintf("%v", value))
+		assert.False(t, toolbox.AsBoolean(value))
+	}
+}
+
+func TestToBooleanStrict(t *testing.T) {
+	var boolValue = true
+	var useCases = map[interface{}]bool{
+		true:    true,
+		false:   false,
+		"true":  true,
+		"FALSE": false,
+		"1":     true,
+		"0":     false,
+		1:       true,
+		0:       false,
+		"yes":   true,
+		"No":    false,
+		"ON":    true,
+		"off":   false,
+	}
+	for value, expected := range useCases {
+		result, err := toolbox.ToBooleanStrict(value)
+		assert.Nil(t, err, fmt.Sprintf("%v", value))
+		assert.Equal(t, expected, result, fmt.Sprintf("%v", value))
+	}
+	result, err := toolbox.ToBooleanStrict(&boolValue)
+	assert.Nil(t, err)
+	assert.True(t, result)
+	for _, value := range []interface{}{"tru", "", "t", "y", 2, 1.1, nil, (*bool)(nil)} {
+		_, err := toolbox.ToBooleanStrict(value)
+		assert.NotNil(t, err, fmt.Sprintf("%v", value))
+	}
+}
+
 func TestDiscoverValueAndKind(t *testing.T) {
 	{
 		value, kind := toolbox.DiscoverValueAndKind("true")
@@ -566,3 +661,122 @@ func TestConverter_AsInt(t *testing.T) {
 	assert.True(t, intValue > 0)
 
 }
+
+func TestParseTimeWithLayouts(t *testing.T) {
+	{
+		timeValue, err := toolbox.ParseTimeWithLayouts("22/02/2016 12:32:01", "yyyy-MM-dd HH:mm:ss", "dd/MM/yyyy HH:mm:ss")
+		assert.Nil(t, err)
+		assert.Equal(t, int64(1456144321), timeValue.Unix())
+	}
+	{
+		timeValue, err := toolbox.ParseTimeWithLayouts("2016-02-22 12:32:01", "yyyy-MM-dd HH:mm:ss", "dd/MM/yyyy HH:mm:ss")
+		assert.Nil(t, err)
+		assert.Equal(t, int64(1456144321), timeValue.Unix())
+	}
+	{
+		_, err := toolbox.ParseTimeWithLayouts("22.02.2016", "yyyy-MM-dd", "dd/MM/yyyy")
+		if assert.NotNil(t, err) {
+			assert.Contains(t, err.Error(), "yyyy-MM-dd")
+			assert.Contains(t, err.Error(), "dd/MM/yyyy")
+		}
+	}
+	{
+		_, err := toolbox.ParseTimeWithLayouts("2016-02-22")
+		assert.NotNil(t, err)
+	}
+}
+
+func TestParseTimeInLocation(t *testing.T) {
+	newYork, err := toolbox.ParseTimeInLocation("2017-07-14 02:40:00", "yyyy-MM-dd HH:mm:ss", "America/New_York")
+	if !assert.Nil(t, err) {
+		return
+	}
+	tokyo, err := toolbox.ParseTimeInLocation("2017-07-14 02:40:00", "yyyy-MM-dd HH:mm:ss", "Asia/Tokyo")
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, "America/New_York", newYork.Location().String())
+	assert.Equal(t, 2, newYork.Hour())
+	assert.Equal(t, 13*time.Hour, newYork.Sub(tokyo))
+	assert.Equal(t, int64(1500014400), newYork.Unix())
+	{ //explicit offset takes precedence over location
+		value, err := toolbox.ParseTimeInLocation("2017-07-14 02:40:00 +0000", "yyyy-MM-dd HH:mm:ss ZZ", "Asia/Tokyo")
+		assert.Nil(t, err)
+		assert.Equal(t, int64(1500000000), value.Unix())
+	}
+	{
+		_, err := toolbox.ParseTimeInLocation("2017-07-14 02:40:00", "yyyy-MM-dd HH:mm:ss", "Mars/Olympus_Mons")
+		if assert.NotNil(t, err) {
+			assert.Contains(t, err.Error(), "Mars/Olympus_Mons")
+		}
+	}
+	{
+		_, err := toolbox.ParseTimeInLocation("14/07/2017", "yyyy-MM-dd", "UTC")
+		assert.NotNil(t, err)
+	}
+}
+
+func TestAsTimeWithLayouts(t *testing.T) {
+	var expected = time.Date(2017, 7, 14, 2, 40, 0, 0, time.UTC)
+	var useCases = []struct {
+		description string
+		value       interface{}
+		layouts     []string
+	}{
+		{"unix seconds", 1500000000, nil},
+		{"unix seconds as text", "1500000000", nil},
+		{"unix milliseconds", int64(1500000000000), nil},
+		{"unix milliseconds as float", 1500000000000.0, nil},
+		{"unix microseconds", int64(1500000000000000), nil},
+		{"unix nanoseconds", int64(1500000000000000000), nil},
+		{"java layout fallback", "14/07/2017 02:40:00", []string{"yyyy-MM-dd HH:mm:ss", "dd/MM/yyyy HH:mm:ss"}},
+		{"RFC3339 default", "2017-07-14T02:40:00Z", []string{"dd/MM/yyyy"}},
+		{"RFC3339 default without layouts", "2017-07-14T02:40:00Z", nil},
+		{"default date layout", "2017-07-14 02:40:00", nil},
+		{"time value", expected, nil},
+	}
+	for _, useCase := range useCases {
+		timeValue := toolbox.AsTimeWithLayouts(useCase.value, useCase.layouts...)
+		if assert.NotNil(t, timeValue, useCase.description) {
+			assert.True(t, expected.Equal(*timeValue), fmt.Sprintf("%v: %v", useCase.description, timeValue))
+		}
+	}
+	{
+		timeValue := toolbox.AsTimeWithLayouts(1500000000.5)
+		if assert.NotNil(t, timeValue) {
+			assert.Equal(t, int64(1500000000500), timeValue.UnixNano()/int64(time.Millisecond))
+		}
+	}
+	assert.Nil(t, toolbox.AsTimeWithLayouts("not a time", "yyyy-MM-dd"))
+
+	{ //numeric text matching layout is not an epoch
+		timeValue := toolbox.AsTimeWithLayouts("20170205", "yyyyMMdd")
+		if assert.NotNil(t, timeValue) {
+			assert.Equal(t, "2017-02-05", timeValue.Format("2006-01-02"))
+		}
+	}
+	{ //large millisecond and microsecond epochs do not overflow
+		timeValue := toolbox.AsTimeWithLayouts(int64(5e13))
+		if assert.NotNil(t, timeValue) {
+			assert.Equal(t, int64(5e13), timeValue.Unix()*1000+int64(timeValue.Nanosecond()/int(time.Millisecond)))
+			assert.Equal(t, 3554, timeValue.UTC().Year())
+		}
+		timeValue = toolbox.AsTimeWithLayouts(int64(9e16))
+		if assert.NotNil(t, timeValue) {
+			assert.Equal(t, int64(9e16), timeValue.Unix()*1000000+int64(timeValue.Nanosecond()/1000))
+		}
+	}
+	{ //integer epochs keep full precision
+		for _, value := range []interface{}{int64(1510000000123456789), "1510000000123456789", uint64(1510000000123456789)} {
+			timeValue := toolbox.AsTimeWithLayouts(value)
+			if assert.NotNil(t, timeValue, fmt.Sprintf("%T", value)) {
+				assert.Equal(t, int64(1510000000123456789), timeValue.UnixNano(), fmt.Sprintf("%T", value))
+			}
+		}
+	}
+	{ //non finite numbers are not epochs
+		for _, value := range []interface{}{"NaN", "Inf", "-Inf", math.NaN(), math.Inf(1)} {
+			assert.Nil(t, toolbox.AsTimeWithLayouts(value), fmt.Sprintf("%v", value))
+		}
+	}
+}
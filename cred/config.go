@@ -4,7 +4,10 @@ import (
 	"bytes"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
 	"gopkg.in/yaml.v2"
 	"io/ioutil"
 	"os"
@@ -13,6 +16,7 @@ import (
 )
 
 var sshKeyFileCandidates = []string{"/.ssh/id_rsa", "/.ssh/id_dsa"}
+var sshKnownHostsFile = "/.ssh/known_hosts"
 var DefaultKey = []byte{0x24, 0x66, 0xDD, 0x87, 0x8B, 0x96, 0x3C, 0x9D}
 var PasswordCipher = GetDefaultPasswordCipher()
 
@@ -21,7 +25,11 @@ type Config struct {
 	Password          string
 	EncryptedPassword string
 	PrivateKeyPath    string
-	clientConfig      *ssh.ClientConfig
+	//KnownHostsPath known hosts file used to verify host keys, defaults to $HOME/.ssh/known_hosts
+	KnownHostsPath string
+	//InsecureIgnoreHostKey accepts any host key without known hosts file, by default unknown hosts and missing known hosts file are rejected
+	InsecureIgnoreHostKey bool
+	clientConfig          *ssh.ClientConfig
 }
 
 func (c *Config) Load(filename string) error {
@@ -46,6 +54,11 @@ func (c *Config) Load(filename string) error {
 		}
 	}
 
+	return c.applyPassword()
+}
+
+//applyPassword decrypts encrypted password or encrypts plain password
+func (c *Config) applyPassword() error {
 	if c.EncryptedPassword != "" {
 		decoder := base64.NewDecoder(base64.StdEncoding, strings.NewReader(c.EncryptedPassword))
 		data, err := ioutil.ReadAll(decoder)
@@ -106,15 +119,38 @@ func (c *Config) applyDefaultIfNeeded() {
 	}
 }
 
+//hostKeyCallback returns host key callback verifying keys against known hosts file, hosts not listed in the file are rejected
+func (c *Config) hostKeyCallback() (ssh.HostKeyCallback, error) {
+	if c.InsecureIgnoreHostKey {
+		return ssh.InsecureIgnoreHostKey(), nil
+	}
+	var knownHostsPath = c.KnownHostsPath
+	if knownHostsPath == "" {
+		homeDirectory := os.Getenv("HOME")
+		if homeDirectory == "" {
+			return nil, errors.New("failed to locate known hosts file: HOME is not set, set KnownHostsPath or InsecureIgnoreHostKey")
+		}
+		knownHostsPath = path.Join(homeDirectory, sshKnownHostsFile)
+	}
+	if _, err := os.Stat(knownHostsPath); err != nil {
+		return nil, fmt.Errorf("failed to load known hosts file %v: %v, set KnownHostsPath or InsecureIgnoreHostKey", knownHostsPath, err)
+	}
+	return knownhosts.New(knownHostsPath)
+}
+
 //ClientConfig returns a new instance of sshClientConfig
 func (c *Config) ClientConfig() (*ssh.ClientConfig, error) {
 	if c.clientConfig != nil {
 		return c.clientConfig, nil
 	}
 	c.applyDefaultIfNeeded()
+	hostKeyCallback, err := c.hostKeyCallback()
+	if err != nil {
+		return nil, err
+	}
 	result := &ssh.ClientConfig{
 		User:            c.Username,
-		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
+		HostKeyCallback: hostKeyCallback,
 		Auth:            make([]ssh.AuthMethod, 0),
 	}
 
@@ -148,6 +184,23 @@ func NewConfig(filename string) (*Config, error) {
 	return config, nil
 }
 
+//NewConfigFromMap creates a new config from decoded credential map, passwords and defaults are applied as with file based config
+func NewConfigFromMap(source map[string]interface{}) (*Config, error) {
+	data, err := json.Marshal(source)
+	if err != nil {
+		return nil, err
+	}
+	var config = &Config{}
+	if err = json.Unmarshal(data, config); err != nil {
+		return nil, err
+	}
+	if err = config.applyPassword(); err != nil {
+		return nil, err
+	}
+	config.applyDefaultIfNeeded()
+	return config, nil
+}
+
 func GetDefaultPasswordCipher() Cipher {
 	var result, err = NewBlowfishCipher(DefaultKey)
 	if err != nil {
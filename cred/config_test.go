@@ -1,9 +1,14 @@
 package cred_test
 
 import (
+	"crypto/rand"
+	"crypto/rsa"
 	"github.com/stretchr/testify/assert"
 	"github.com/viant/toolbox/cred"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
 	"io/ioutil"
+	"net"
 	"os"
 	"path"
 	"testing"
@@ -37,3 +42,67 @@ func TestConfig_Load(t *testing.T) {
 	_ = os.Remove(testFile)
 
 }
+
+func newTestPublicKey(t *testing.T) ssh.PublicKey {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+	publicKey, err := ssh.NewPublicKey(&privateKey.PublicKey)
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+	return publicKey
+}
+
+func TestConfig_ClientConfig_HostKey(t *testing.T) {
+	var knownHostsFile = path.Join(os.TempDir(), "credTestKnownHosts")
+	defer os.Remove(knownHostsFile)
+	knownKey, otherKey := newTestPublicKey(t), newTestPublicKey(t)
+	err := ioutil.WriteFile(knownHostsFile, []byte(knownhosts.Line([]string{"known.host"}, knownKey)+"\n"), 0644)
+	assert.Nil(t, err)
+	var remote = &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 22}
+
+	{ //listed hosts are verified, unknown hosts are rejected by default
+		config := &cred.Config{Username: "test", Password: "abc", KnownHostsPath: knownHostsFile}
+		clientConfig, err := config.ClientConfig()
+		if assert.Nil(t, err) {
+			assert.Nil(t, clientConfig.HostKeyCallback("known.host:22", remote, knownKey))
+			assert.NotNil(t, clientConfig.HostKeyCallback("known.host:22", remote, otherKey))
+			assert.NotNil(t, clientConfig.HostKeyCallback("unknown.host:22", remote, otherKey))
+		}
+	}
+	{ //missing known hosts file is rejected by default
+		config := &cred.Config{Username: "test", Password: "abc", KnownHostsPath: path.Join(os.TempDir(), "credTestMissingKnownHosts")}
+		_, err := config.ClientConfig()
+		assert.NotNil(t, err)
+	}
+	{ //default known hosts file is required
+		var home = os.Getenv("HOME")
+		defer os.Setenv("HOME", home)
+		os.Setenv("HOME", path.Join(os.TempDir(), "credTestMissingHome"))
+		config := &cred.Config{Username: "test", Password: "abc"}
+		_, err := config.ClientConfig()
+		assert.NotNil(t, err)
+	}
+	{ //explicit opt-out accepts any host
+		config := &cred.Config{Username: "test", Password: "abc", KnownHostsPath: path.Join(os.TempDir(), "credTestMissingKnownHosts"), InsecureIgnoreHostKey: true}
+		clientConfig, err := config.ClientConfig()
+		if assert.Nil(t, err) {
+			assert.Nil(t, clientConfig.HostKeyCallback("unknown.host:22", remote, otherKey))
+		}
+	}
+}
+
+func TestNewConfigFromMap(t *testing.T) {
+	config, err := cred.NewConfigFromMap(map[string]interface{}{"username": "adrian", "Password": "abc"})
+	if assert.Nil(t, err) {
+		assert.Equal(t, "adrian", config.Username)
+		assert.Equal(t, "abc", config.Password)
+		assert.Equal(t, "AAAAAAAAAAAXUPcVbxwWlQ==", config.EncryptedPassword)
+	}
+	config, err = cred.NewConfigFromMap(map[string]interface{}{"Username": "adrian", "EncryptedPassword": "AAAAAAAAAAAXUPcVbxwWlQ=="})
+	if assert.Nil(t, err) {
+		assert.Equal(t, "abc", config.Password)
+	}
+}
@@ -0,0 +1,104 @@
+package toolbox
+
+import (
+	"bytes"
+	"encoding/base32"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+const hexDumpBytesPerLine = 16
+const hexDumpColumnWidth = 39
+
+type encodeProvider struct{}
+
+func (p encodeProvider) hexDump(data []byte) string {
+	var result = new(bytes.Buffer)
+	for offset := 0; offset < len(data); offset += hexDumpBytesPerLine {
+		var end = offset + hexDumpBytesPerLine
+		if end > len(data) {
+			end = len(data)
+		}
+		var line = data[offset:end]
+		var hexColumn = new(bytes.Buffer)
+		for i := 0; i < len(line); i++ {
+			if i > 0 && i%2 == 0 {
+				hexColumn.WriteString(" ")
+			}
+			hexColumn.WriteString(hex.EncodeToString(line[i : i+1]))
+		}
+		var asciiColumn = make([]byte, len(line))
+		for i, aByte := range line {
+			asciiColumn[i] = '.'
+			if aByte >= 32 && aByte < 127 {
+				asciiColumn[i] = aByte
+			}
+		}
+		result.WriteString(fmt.Sprintf("%08x: %-*s  %s\n", offset, hexDumpColumnWidth, hexColumn.String(), asciiColumn))
+	}
+	return result.String()
+}
+
+func (p encodeProvider) hexUndump(dump string) ([]byte, error) {
+	var result = new(bytes.Buffer)
+	for i, line := range strings.Split(dump, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		offsetPosition := strings.Index(line, ": ")
+		if offsetPosition == -1 {
+			return nil, fmt.Errorf("invalid hexdump line %v: %v", i+1, line)
+		}
+		var hexColumn = line[offsetPosition+2:]
+		if len(hexColumn) > hexDumpColumnWidth {
+			hexColumn = hexColumn[:hexDumpColumnWidth]
+		}
+		decoded, err := hex.DecodeString(strings.Replace(hexColumn, " ", "", -1))
+		if err != nil {
+			return nil, fmt.Errorf("invalid hexdump line %v: %v", i+1, err)
+		}
+		result.Write(decoded)
+	}
+	return result.Bytes(), nil
+}
+
+func (p encodeProvider) Get(context Context, arguments ...interface{}) (interface{}, error) {
+	if len(arguments) < 2 {
+		return nil, fmt.Errorf("expected at least 2 arguments (operation, value) but had: %v", len(arguments))
+	}
+	var operation = strings.ToLower(AsString(arguments[0]))
+	var data = []byte(AsString(arguments[1]))
+	var decode = len(arguments) > 2 && (AsBoolean(arguments[2]) || strings.ToLower(AsString(arguments[2])) == "decode")
+	var err error
+	switch operation {
+	case "base32":
+		if !decode {
+			return base32.StdEncoding.EncodeToString(data), nil
+		}
+		data, err = base32.StdEncoding.DecodeString(string(data))
+	case "hex":
+		if !decode {
+			return hex.EncodeToString(data), nil
+		}
+		data, err = hex.DecodeString(string(data))
+	case "hexdump":
+		if !decode {
+			return p.hexDump(data), nil
+		}
+		data, err = p.hexUndump(string(data))
+	default:
+		return nil, fmt.Errorf("unsupported encode operation: %v", operation)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %v: %v", operation, err)
+	}
+	return string(data), nil
+}
+
+//NewEncodeProvider returns a provider that encodes value (second argument) with base32, hex or hexdump (xxd style) operation (first argument),
+//optional "decode" (or true) third argument reverses operation.
+func NewEncodeProvider() ValueProvider {
+	var result ValueProvider = &encodeProvider{}
+	return result
+}
@@ -0,0 +1,54 @@
+package toolbox_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/toolbox"
+)
+
+func TestNewEncodeProvider(t *testing.T) {
+	provider := toolbox.NewEncodeProvider()
+	{
+		value, err := provider.Get(nil, "base32", "hello")
+		assert.Nil(t, err)
+		assert.Equal(t, "NBSWY3DP", value)
+		value, err = provider.Get(nil, "base32", value, "decode")
+		assert.Nil(t, err)
+		assert.Equal(t, "hello", value)
+	}
+	{
+		value, err := provider.Get(nil, "hex", []byte("hello"))
+		assert.Nil(t, err)
+		assert.Equal(t, "68656c6c6f", value)
+		value, err = provider.Get(nil, "hex", value, true)
+		assert.Nil(t, err)
+		assert.Equal(t, "hello", value)
+	}
+	{
+		var text = "hello world, this is a hexdump\n"
+		value, err := provider.Get(nil, "hexdump", text)
+		assert.Nil(t, err)
+		assert.Equal(t, "00000000: 6865 6c6c 6f20 776f 726c 642c 2074 6869  hello world, thi\n"+
+			"00000010: 7320 6973 2061 2068 6578 6475 6d70 0a    s is a hexdump.\n", value)
+		value, err = provider.Get(nil, "hexdump", value, "decode")
+		assert.Nil(t, err)
+		assert.Equal(t, text, value)
+	}
+	{
+		_, err := provider.Get(nil, "hex", "xyz", "decode")
+		assert.NotNil(t, err)
+	}
+	{
+		_, err := provider.Get(nil, "base32", "1", "decode")
+		assert.NotNil(t, err)
+	}
+	{
+		_, err := provider.Get(nil, "hexdump", "abc", "decode")
+		assert.NotNil(t, err)
+	}
+	{
+		_, err := provider.Get(nil, "rot13", "abc")
+		assert.NotNil(t, err)
+	}
+}
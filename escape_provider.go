@@ -0,0 +1,45 @@
+package toolbox
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"strings"
+)
+
+type escapeProvider struct{}
+
+func (p escapeProvider) Get(context Context, arguments ...interface{}) (interface{}, error) {
+	if len(arguments) != 2 {
+		return nil, fmt.Errorf("expected 2 arguments (mode, value) but had: %v", len(arguments))
+	}
+	var mode = strings.ToLower(AsString(arguments[0]))
+	var value = AsString(arguments[1])
+	switch mode {
+	case "shell":
+		return "'" + strings.Replace(value, "'", `'\''`, -1) + "'", nil
+	case "sql":
+		return "'" + strings.Replace(value, "'", "''", -1) + "'", nil
+	case "html":
+		return html.EscapeString(value), nil
+	case "json":
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		return string(encoded), nil
+	case "csv":
+		if value == "" || (!strings.ContainsAny(value, ",\"\r\n") && value == strings.TrimSpace(value)) {
+			return value, nil
+		}
+		return `"` + strings.Replace(value, `"`, `""`, -1) + `"`, nil
+	}
+	return nil, fmt.Errorf("unsupported escape mode: %v", mode)
+}
+
+//NewEscapeProvider returns a provider that escapes value (second argument) for shell, sql, html, json or csv mode (first argument),
+//shell and sql produce single quoted token, json produces double quoted string literal.
+func NewEscapeProvider() ValueProvider {
+	var result ValueProvider = &escapeProvider{}
+	return result
+}
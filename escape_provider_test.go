@@ -0,0 +1,42 @@
+package toolbox_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/toolbox"
+)
+
+func TestNewEscapeProvider(t *testing.T) {
+	provider := toolbox.NewEscapeProvider()
+
+	var useCases = []struct {
+		mode     string
+		value    interface{}
+		expected string
+	}{
+		{"shell", "it's $HOME", `'it'\''s $HOME'`},
+		{"sql", "O'Brien", `'O''Brien'`},
+		{"html", `<a href="x">&</a>`, "&lt;a href=&#34;x&#34;&gt;&amp;&lt;/a&gt;"},
+		{"json", "say \"hi\"\n", `"say \"hi\"\n"`},
+		{"json", 12, `"12"`},
+		{"csv", "plain", "plain"},
+		{"csv", `a,"b"`, `"a,""b"""`},
+		{"csv", " padded", `" padded"`},
+		{"SHELL", "", `''`},
+	}
+	for _, useCase := range useCases {
+		value, err := provider.Get(nil, useCase.mode, useCase.value)
+		if assert.Nil(t, err, useCase.mode) {
+			assert.Equal(t, useCase.expected, value, useCase.mode)
+		}
+	}
+	{
+		_, err := provider.Get(nil, "xml", "abc")
+		assert.NotNil(t, err)
+	}
+	{
+		_, err := provider.Get(nil, "shell")
+		assert.NotNil(t, err)
+	}
+}
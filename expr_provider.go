@@ -0,0 +1,195 @@
+package toolbox
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+//exprValue represents arithmetic expression intermediate value, integer values stay integers as long as operations are exact
+type exprValue struct {
+	intValue   int
+	floatValue float64
+	isFloat    bool
+}
+
+func (v exprValue) float() float64 {
+	if v.isFloat {
+		return v.floatValue
+	}
+	return float64(v.intValue)
+}
+
+func (v exprValue) value() interface{} {
+	if v.isFloat {
+		return v.floatValue
+	}
+	return v.intValue
+}
+
+type exprParser struct {
+	expression string
+	position   int
+}
+
+func (p *exprParser) skipWhitespaces() {
+	for p.position < len(p.expression) && strings.ContainsRune(" \t\r\n", rune(p.expression[p.position])) {
+		p.position++
+	}
+}
+
+func (p *exprParser) peek() byte {
+	p.skipWhitespaces()
+	if p.position < len(p.expression) {
+		return p.expression[p.position]
+	}
+	return 0
+}
+
+func (p *exprParser) apply(operator byte, left, right exprValue) (exprValue, error) {
+	if !left.isFloat && !right.isFloat {
+		switch operator {
+		case '+':
+			return exprValue{intValue: left.intValue + right.intValue}, nil
+		case '-':
+			return exprValue{intValue: left.intValue - right.intValue}, nil
+		case '*':
+			return exprValue{intValue: left.intValue * right.intValue}, nil
+		case '/':
+			if right.intValue == 0 {
+				return exprValue{}, fmt.Errorf("division by zero at position %v", p.position)
+			}
+			if left.intValue%right.intValue == 0 {
+				return exprValue{intValue: left.intValue / right.intValue}, nil
+			}
+		}
+	}
+	var result = exprValue{isFloat: true}
+	switch operator {
+	case '+':
+		result.floatValue = left.float() + right.float()
+	case '-':
+		result.floatValue = left.float() - right.float()
+	case '*':
+		result.floatValue = left.float() * right.float()
+	case '/':
+		if right.float() == 0 {
+			return exprValue{}, fmt.Errorf("division by zero at position %v", p.position)
+		}
+		result.floatValue = left.float() / right.float()
+	}
+	return result, nil
+}
+
+//parseExpression parses: term {("+"|"-") term}
+func (p *exprParser) parseExpression() (exprValue, error) {
+	result, err := p.parseTerm()
+	if err != nil {
+		return result, err
+	}
+	for operator := p.peek(); operator == '+' || operator == '-'; operator = p.peek() {
+		p.position++
+		right, err := p.parseTerm()
+		if err != nil {
+			return result, err
+		}
+		if result, err = p.apply(operator, result, right); err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+//parseTerm parses: factor {("*"|"/") factor}
+func (p *exprParser) parseTerm() (exprValue, error) {
+	result, err := p.parseFactor()
+	if err != nil {
+		return result, err
+	}
+	for operator := p.peek(); operator == '*' || operator == '/'; operator = p.peek() {
+		p.position++
+		right, err := p.parseFactor()
+		if err != nil {
+			return result, err
+		}
+		if result, err = p.apply(operator, result, right); err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+//parseFactor parses: ("+"|"-") factor | "(" expression ")" | number
+func (p *exprParser) parseFactor() (exprValue, error) {
+	switch aChar := p.peek(); {
+	case aChar == '+' || aChar == '-':
+		p.position++
+		result, err := p.parseFactor()
+		if err != nil || aChar == '+' {
+			return result, err
+		}
+		return p.apply('-', exprValue{}, result)
+	case aChar == '(':
+		p.position++
+		result, err := p.parseExpression()
+		if err != nil {
+			return result, err
+		}
+		if p.peek() != ')' {
+			return result, fmt.Errorf("expected ')' at position %v", p.position)
+		}
+		p.position++
+		return result, nil
+	case aChar == '.' || (aChar >= '0' && aChar <= '9'):
+		return p.parseNumber()
+	case aChar == 0:
+		return exprValue{}, fmt.Errorf("unexpected end of expression")
+	}
+	return exprValue{}, fmt.Errorf("unexpected '%c' at position %v", p.expression[p.position], p.position)
+}
+
+func (p *exprParser) parseNumber() (exprValue, error) {
+	var start = p.position
+	for p.position < len(p.expression) && (p.expression[p.position] == '.' || (p.expression[p.position] >= '0' && p.expression[p.position] <= '9')) {
+		p.position++
+	}
+	var literal = p.expression[start:p.position]
+	if !strings.Contains(literal, ".") {
+		if intValue, err := strconv.Atoi(literal); err == nil {
+			return exprValue{intValue: intValue}, nil
+		}
+	}
+	floatValue, err := strconv.ParseFloat(literal, 64)
+	if err != nil {
+		return exprValue{}, fmt.Errorf("invalid number '%v' at position %v", literal, start)
+	}
+	return exprValue{floatValue: floatValue, isFloat: true}, nil
+}
+
+//EvaluateExpression evaluates arithmetic expression with + - * / operators, parentheses and numeric literals,
+//it returns int if all operations are exact on integers, otherwise float64.
+func EvaluateExpression(expression string) (interface{}, error) {
+	parser := &exprParser{expression: expression}
+	result, err := parser.parseExpression()
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate '%v': %v", expression, err)
+	}
+	if parser.peek() != 0 {
+		return nil, fmt.Errorf("failed to evaluate '%v': unexpected '%c' at position %v", expression, parser.expression[parser.position], parser.position)
+	}
+	return result.value(), nil
+}
+
+type exprProvider struct{}
+
+func (p exprProvider) Get(context Context, arguments ...interface{}) (interface{}, error) {
+	if len(arguments) == 0 {
+		return nil, fmt.Errorf("expected at least one argument but had 0")
+	}
+	return EvaluateExpression(AsString(arguments[0]))
+}
+
+//NewExprProvider returns a provider that evaluates basic arithmetic expression passed as the first argument (see EvaluateExpression)
+func NewExprProvider() ValueProvider {
+	return &exprProvider{}
+}
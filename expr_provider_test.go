@@ -0,0 +1,42 @@
+package toolbox_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/toolbox"
+)
+
+func TestNewExprProvider(t *testing.T) {
+	provider := toolbox.NewExprProvider()
+	var useCases = []struct {
+		expression string
+		expected   interface{}
+	}{
+		{"1 + 2", 3},
+		{"2 + 3 * 4", 14},
+		{"(2 + 3) * 4", 20},
+		{"10 - 4 - 3", 3},
+		{"100 / 10 / 5", 2},
+		{"7 / 2", 3.5},
+		{"10 * 1.2", 12.0},
+		{"-3 + 5", 2},
+		{"-(2 + 3) * -2", 10},
+		{"((1 + 2) * (3 + 4)) / 7", 3},
+		{" 2.5 * 2 ", 5.0},
+		{".5 + 1", 1.5},
+		{"42", 42},
+	}
+	for _, useCase := range useCases {
+		value, err := provider.Get(nil, useCase.expression)
+		assert.Nil(t, err, useCase.expression)
+		assert.Equal(t, useCase.expected, value, useCase.expression)
+	}
+
+	for _, expression := range []string{"1 / 0", "1.5 / (2 - 2)", "", "1 +", "(1 + 2", "1 + 2)", "2 ** 3", "price * 1.2", "1..2 + 3", "3 4"} {
+		_, err := provider.Get(nil, expression)
+		assert.NotNil(t, err, expression)
+	}
+	_, err := provider.Get(nil)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,18 @@
+package toolbox
+
+import "fmt"
+
+type formatProvider struct{}
+
+func (p formatProvider) Get(context Context, arguments ...interface{}) (interface{}, error) {
+	if len(arguments) == 0 {
+		return nil, fmt.Errorf("expected at least one argument but had 0")
+	}
+	return fmt.Sprintf(AsString(arguments[0]), arguments[1:]...), nil
+}
+
+//NewFormatProvider returns a provider that formats operands (remaining arguments) with fmt.Sprintf format (first argument)
+func NewFormatProvider() ValueProvider {
+	var result ValueProvider = &formatProvider{}
+	return result
+}
@@ -0,0 +1,31 @@
+package toolbox_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/toolbox"
+)
+
+func TestNewFormatProvider(t *testing.T) {
+	provider := toolbox.NewFormatProvider()
+	var useCases = []struct {
+		arguments []interface{}
+		expected  string
+	}{
+		{[]interface{}{"%s-%s", "a", "b"}, "a-b"},
+		{[]interface{}{"id:%d", 12}, "id:12"},
+		{[]interface{}{"[%5s|%-4d|%05.1f]", "ab", 7, 3.14159}, "[   ab|7   |003.1]"},
+		{[]interface{}{"%s and %s", "one"}, "one and %!s(MISSING)"},
+		{[]interface{}{"plain"}, "plain"},
+	}
+	for _, useCase := range useCases {
+		value, err := provider.Get(nil, useCase.arguments...)
+		assert.Nil(t, err)
+		assert.Equal(t, useCase.expected, value)
+	}
+	{
+		_, err := provider.Get(nil)
+		assert.NotNil(t, err)
+	}
+}
@@ -0,0 +1,58 @@
+package toolbox
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+const earthRadiusInKm = 6371.0088
+
+var distanceUnitFactors = map[string]float64{
+	"km": 1.0,
+	"m":  1000.0,
+	"mi": 0.621371192,
+}
+
+type geoDistanceProvider struct{}
+
+func (p geoDistanceProvider) Get(context Context, arguments ...interface{}) (interface{}, error) {
+	if len(arguments) < 4 {
+		return nil, fmt.Errorf("expected at least 4 arguments (lat1, lon1, lat2, lon2) but had: %v", len(arguments))
+	}
+	var coordinates = make([]float64, 4)
+	for i := 0; i < 4; i++ {
+		coordinates[i] = AsFloat(arguments[i])
+		var limit = 180.0
+		if i%2 == 0 {
+			limit = 90.0
+		}
+		if math.Abs(coordinates[i]) > limit {
+			return nil, fmt.Errorf("coordinate out of range: %v", arguments[i])
+		}
+	}
+	var unit = "km"
+	if len(arguments) > 4 {
+		unit = strings.ToLower(AsString(arguments[4]))
+	}
+	factor, ok := distanceUnitFactors[unit]
+	if !ok {
+		return nil, fmt.Errorf("unsupported distance unit: %v", unit)
+	}
+	var toRadians = func(degree float64) float64 {
+		return degree * math.Pi / 180
+	}
+	lat1, lat2 := toRadians(coordinates[0]), toRadians(coordinates[2])
+	latDelta := lat2 - lat1
+	lonDelta := toRadians(coordinates[3] - coordinates[1])
+	haversine := math.Pow(math.Sin(latDelta/2), 2) + math.Cos(lat1)*math.Cos(lat2)*math.Pow(math.Sin(lonDelta/2), 2)
+	distance := 2 * earthRadiusInKm * math.Asin(math.Sqrt(haversine))
+	return distance * factor, nil
+}
+
+//NewGeoDistanceProvider returns a provider that computes great-circle distance between lat1, lon1, lat2, lon2 coordinates,
+//optional fifth argument specifies unit: km (default), mi or m
+func NewGeoDistanceProvider() ValueProvider {
+	var result ValueProvider = &geoDistanceProvider{}
+	return result
+}
@@ -0,0 +1,48 @@
+package toolbox_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/toolbox"
+)
+
+func TestNewGeoDistanceProvider(t *testing.T) {
+	provider := toolbox.NewGeoDistanceProvider()
+	{
+		value, err := provider.Get(nil, 51.5074, -0.1278, "48.8566", "2.3522")
+		assert.Nil(t, err)
+		assert.InDelta(t, 343.5, toolbox.AsFloat(value), 1.0)
+	}
+	{
+		value, err := provider.Get(nil, 51.5074, -0.1278, 48.8566, 2.3522, "mi")
+		assert.Nil(t, err)
+		assert.InDelta(t, 213.5, toolbox.AsFloat(value), 1.0)
+	}
+	{
+		value, err := provider.Get(nil, 51.5074, -0.1278, 48.8566, 2.3522, "m")
+		assert.Nil(t, err)
+		assert.InDelta(t, 343500, toolbox.AsFloat(value), 1000)
+	}
+	{
+		value, err := provider.Get(nil, 10, 10, 10, 10)
+		assert.Nil(t, err)
+		assert.Equal(t, 0.0, value)
+	}
+	{
+		_, err := provider.Get(nil, 91, 0, 0, 0)
+		assert.NotNil(t, err)
+	}
+	{
+		_, err := provider.Get(nil, 0, 181, 0, 0)
+		assert.NotNil(t, err)
+	}
+	{
+		_, err := provider.Get(nil, 0, 0, 0, 0, "ft")
+		assert.NotNil(t, err)
+	}
+	{
+		_, err := provider.Get(nil, 0, 0, 0)
+		assert.NotNil(t, err)
+	}
+}
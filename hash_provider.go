@@ -0,0 +1,47 @@
+package toolbox
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"hash/fnv"
+)
+
+type hashProvider struct{}
+
+func (p hashProvider) Get(context Context, arguments ...interface{}) (interface{}, error) {
+	if len(arguments) != 2 {
+		return nil, fmt.Errorf("expected 2 arguments (algorithm, input) but had %v", len(arguments))
+	}
+	var input []byte
+	if arguments[1] != nil {
+		input = []byte(AsString(arguments[1]))
+	}
+	var hasher hash.Hash
+	switch algorithm := AsString(arguments[0]); algorithm {
+	case "md5":
+		hasher = md5.New()
+	case "sha1":
+		hasher = sha1.New()
+	case "sha256":
+		hasher = sha256.New()
+	case "fnv":
+		fnvHash := fnv.New64a()
+		fnvHash.Write(input)
+		return fnvHash.Sum64(), nil
+	default:
+		return nil, fmt.Errorf("unsupported hash algorithm: %v, expected md5, sha1, sha256 or fnv", algorithm)
+	}
+	hasher.Write(input)
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+//NewHashProvider returns a provider that hashes input (second argument) with algorithm (first argument): md5, sha1 or sha256 return hex digest,
+//fnv returns 64-bit FNV-1a value as uint64. Nil input is hashed as empty string.
+func NewHashProvider() ValueProvider {
+	var result ValueProvider = &hashProvider{}
+	return result
+}
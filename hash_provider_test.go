@@ -0,0 +1,38 @@
+package toolbox_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/toolbox"
+)
+
+func TestNewHashProvider(t *testing.T) {
+	provider := toolbox.NewHashProvider()
+	var useCases = []struct {
+		arguments []interface{}
+		expected  interface{}
+	}{
+		{[]interface{}{"md5", "abc"}, "900150983cd24fb0d6963f7d28e17f72"},
+		{[]interface{}{"sha1", "abc"}, "a9993e364706816aba3e25717850c26c9cd0d89d"},
+		{[]interface{}{"sha256", "abc"}, "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"},
+		{[]interface{}{"sha256", []byte("abc")}, "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"},
+		{[]interface{}{"fnv", "a"}, uint64(0xaf63dc4c8601ec8c)},
+		{[]interface{}{"md5", nil}, "d41d8cd98f00b204e9800998ecf8427e"},
+		{[]interface{}{"sha256", nil}, "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"},
+		{[]interface{}{"fnv", nil}, uint64(0xcbf29ce484222325)},
+		{[]interface{}{"md5", 123}, "202cb962ac59075b964b07152d234b70"},
+	}
+	for _, useCase := range useCases {
+		value, err := provider.Get(nil, useCase.arguments...)
+		assert.Nil(t, err, useCase.arguments)
+		assert.Equal(t, useCase.expected, value, useCase.arguments)
+	}
+	for _, arguments := range [][]interface{}{
+		{"md5"},
+		{"crc32", "abc"},
+	} {
+		_, err := provider.Get(nil, arguments...)
+		assert.NotNil(t, err, arguments)
+	}
+}
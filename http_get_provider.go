@@ -0,0 +1,53 @@
+package toolbox
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
+
+type httpGetProvider struct {
+	client *http.Client
+}
+
+func (p httpGetProvider) Get(context Context, arguments ...interface{}) (interface{}, error) {
+	if len(arguments) == 0 {
+		return nil, fmt.Errorf("expected at least one argument but had 0")
+	}
+	var URL = AsString(arguments[0])
+	response, err := p.client.Get(URL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %v: %v", URL, err)
+	}
+	defer response.Body.Close()
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %v response: %v", URL, err)
+	}
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return nil, fmt.Errorf("failed to get %v: status code: %v", URL, response.StatusCode)
+	}
+	if len(arguments) < 2 {
+		return string(body), nil
+	}
+	var document interface{}
+	if err = NewJSONDecoderFactoryWithOption(true).Create(bytes.NewReader(body)).Decode(&document); err != nil {
+		return nil, fmt.Errorf("failed to decode %v response as JSON: %v", URL, err)
+	}
+	value, found := varProvider{}.lookup(document, jsonPathToDotted(AsString(arguments[1])))
+	if !found {
+		return nil, nil
+	}
+	return normalizeJSONValue(value), nil
+}
+
+//NewHTTPGetProvider returns a provider that fetches URL passed as the first argument with supplied client (http.DefaultClient if nil) and returns body as string,
+//optional second argument is a dotted or bracketed path (i.e. data.items[0].id) extracted from JSON body, the same way as with NewJSONPathProvider,
+//numbers are returned as int or float64 and missing path returns nil. Non 2xx status code is an error.
+func NewHTTPGetProvider(client *http.Client) ValueProvider {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &httpGetProvider{client: client}
+}
@@ -0,0 +1,67 @@
+package toolbox_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/toolbox"
+)
+
+func TestNewHTTPGetProvider(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		switch request.URL.Path {
+		case "/text":
+			writer.Write([]byte("hello"))
+		case "/json":
+			writer.Header().Set("Content-Type", "application/json")
+			writer.Write([]byte(`{"data":{"region":"us-east-1","items":[{"id":7}]}}`))
+		case "/slow":
+			time.Sleep(200 * time.Millisecond)
+			writer.Write([]byte("late"))
+		default:
+			writer.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	provider := toolbox.NewHTTPGetProvider(nil)
+	{
+		value, err := provider.Get(nil, server.URL+"/text")
+		assert.Nil(t, err)
+		assert.Equal(t, "hello", value)
+	}
+	{
+		value, err := provider.Get(nil, server.URL+"/json", "data.region")
+		assert.Nil(t, err)
+		assert.Equal(t, "us-east-1", value)
+		value, err = provider.Get(nil, server.URL+"/json", "data.items.0.id")
+		assert.Nil(t, err)
+		assert.Equal(t, 7, value)
+		value, err = provider.Get(nil, server.URL+"/json", "data.items[0].id")
+		assert.Nil(t, err)
+		assert.Equal(t, 7, value)
+		value, err = provider.Get(nil, server.URL+"/json", "data.missing")
+		assert.Nil(t, err)
+		assert.Nil(t, value)
+		_, err = provider.Get(nil, server.URL+"/text", "data")
+		assert.NotNil(t, err)
+	}
+	{
+		_, err := provider.Get(nil, server.URL+"/missing")
+		if assert.NotNil(t, err) {
+			assert.Contains(t, err.Error(), "404")
+		}
+	}
+	{
+		provider := toolbox.NewHTTPGetProvider(&http.Client{Timeout: 20 * time.Millisecond})
+		_, err := provider.Get(nil, server.URL+"/slow")
+		assert.NotNil(t, err)
+	}
+	{
+		_, err := provider.Get(nil)
+		assert.NotNil(t, err)
+	}
+}
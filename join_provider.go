@@ -0,0 +1,33 @@
+package toolbox
+
+import (
+	"fmt"
+	"strings"
+)
+
+type joinProvider struct{}
+
+func (p joinProvider) Get(context Context, arguments ...interface{}) (interface{}, error) {
+	if len(arguments) < 2 {
+		return nil, fmt.Errorf("expected at least 2 arguments but had %v", len(arguments))
+	}
+	var separator = AsString(arguments[len(arguments)-1])
+	var elements = arguments[:len(arguments)-1]
+	if len(elements) == 1 && elements[0] != nil && IsSlice(elements[0]) {
+		elements = AsSlice(elements[0])
+	}
+	var parts = make([]string, len(elements))
+	for i, element := range elements {
+		if element == nil {
+			continue
+		}
+		parts[i] = AsString(element)
+	}
+	return strings.Join(parts, separator), nil
+}
+
+//NewJoinProvider returns a provider that joins slice (first argument) or operands (all but last argument) with separator (last argument), nil elements are joined as empty strings
+func NewJoinProvider() ValueProvider {
+	var result ValueProvider = &joinProvider{}
+	return result
+}
@@ -0,0 +1,33 @@
+package toolbox_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/toolbox"
+)
+
+func TestNewJoinProvider(t *testing.T) {
+	provider := toolbox.NewJoinProvider()
+	var useCases = []struct {
+		arguments []interface{}
+		expected  string
+	}{
+		{[]interface{}{[]interface{}{"a", 1, nil, 2.5, true}, ","}, "a,1,,2.5,true"},
+		{[]interface{}{[]string{"x", "y", "z"}, " | "}, "x | y | z"},
+		{[]interface{}{[]int{1, 2, 3}, ""}, "123"},
+		{[]interface{}{[]interface{}{}, ","}, ""},
+		{[]interface{}{"a", "b", nil, 3, "-"}, "a-b--3"},
+		{[]interface{}{"single", ","}, "single"},
+		{[]interface{}{nil, ","}, ""},
+	}
+	for _, useCase := range useCases {
+		value, err := provider.Get(nil, useCase.arguments...)
+		assert.Nil(t, err)
+		assert.Equal(t, useCase.expected, value)
+	}
+	{
+		_, err := provider.Get(nil, []interface{}{"a"})
+		assert.NotNil(t, err)
+	}
+}
@@ -0,0 +1,108 @@
+package toolbox
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+type jsonDiffProvider struct{}
+
+func (p jsonDiffProvider) asMap(source interface{}) (map[string]interface{}, error) {
+	switch value := source.(type) {
+	case string:
+		return p.decode([]byte(value))
+	case []byte:
+		return p.decode(value)
+	}
+	if source == nil || !IsMap(source) {
+		return nil, fmt.Errorf("expected map or JSON but had %T", source)
+	}
+	return AsMap(source), nil
+}
+
+func (p jsonDiffProvider) decode(data []byte) (map[string]interface{}, error) {
+	var result = make(map[string]interface{})
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to decode JSON: %v", err)
+	}
+	return result, nil
+}
+
+func (p jsonDiffProvider) diff(path string, source, target map[string]interface{}, added, removed, changed map[string]interface{}) {
+	for key, sourceValue := range source {
+		var keyPath = key
+		if path != "" {
+			keyPath = path + "." + key
+		}
+		targetValue, found := target[key]
+		if !found {
+			removed[keyPath] = sourceValue
+			continue
+		}
+		if sourceValue != nil && targetValue != nil && IsMap(sourceValue) && IsMap(targetValue) {
+			p.diff(keyPath, AsMap(sourceValue), AsMap(targetValue), added, removed, changed)
+			continue
+		}
+		if !reflect.DeepEqual(sourceValue, targetValue) {
+			changed[keyPath] = map[string]interface{}{
+				"old": sourceValue,
+				"new": targetValue,
+			}
+		}
+	}
+	for key, targetValue := range target {
+		if _, found := source[key]; found {
+			continue
+		}
+		var keyPath = key
+		if path != "" {
+			keyPath = path + "." + key
+		}
+		added[keyPath] = targetValue
+	}
+}
+
+func (p jsonDiffProvider) Get(context Context, arguments ...interface{}) (interface{}, error) {
+	if len(arguments) < 2 {
+		return nil, fmt.Errorf("expected 2 arguments but had: %v", len(arguments))
+	}
+	source, err := p.asMap(arguments[0])
+	if err != nil {
+		return nil, fmt.Errorf("invalid source: %v", err)
+	}
+	target, err := p.asMap(arguments[1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid target: %v", err)
+	}
+	var added, removed, changed = make(map[string]interface{}), make(map[string]interface{}), make(map[string]interface{})
+	p.diff("", source, target, added, removed, changed)
+
+	if len(arguments) > 2 {
+		var mode = AsString(arguments[2])
+		if mode != "paths" {
+			return nil, fmt.Errorf("unsupported mode: %v", mode)
+		}
+		var result = make([]string, 0)
+		for _, diff := range []map[string]interface{}{added, removed, changed} {
+			for key := range diff {
+				result = append(result, key)
+			}
+		}
+		sort.Strings(result)
+		return result, nil
+	}
+	return map[string]interface{}{
+		"added":   added,
+		"removed": removed,
+		"changed": changed,
+	}, nil
+}
+
+//NewJSONDiffProvider returns a provider that takes two maps or JSON documents and returns a difference report with added, removed and changed dotted paths,
+//changed path holds old and new value. If the optional third argument is "paths", only sorted list of differing paths is returned.
+func NewJSONDiffProvider() ValueProvider {
+	var result ValueProvider = &jsonDiffProvider{}
+	return result
+}
@@ -0,0 +1,48 @@
+package toolbox_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/toolbox"
+)
+
+func TestNewJSONDiffProvider(t *testing.T) {
+	provider := toolbox.NewJSONDiffProvider()
+
+	{
+		value, err := provider.Get(nil, `{"a":1, "b":{"c":"x", "d":true}, "e":[1,2]}`, map[string]interface{}{
+			"a": 2.0,
+			"b": map[string]interface{}{
+				"c": "x",
+				"f": 3.0,
+			},
+			"e": "text",
+		})
+		assert.Nil(t, err)
+		diff := toolbox.AsMap(value)
+		assert.EqualValues(t, map[string]interface{}{"b.f": 3.0}, diff["added"])
+		assert.EqualValues(t, map[string]interface{}{"b.d": true}, diff["removed"])
+		changed := toolbox.AsMap(diff["changed"])
+		assert.Equal(t, 2, len(changed))
+		assert.EqualValues(t, map[string]interface{}{"old": 1.0, "new": 2.0}, changed["a"])
+		assert.EqualValues(t, map[string]interface{}{"old": []interface{}{1.0, 2.0}, "new": "text"}, changed["e"])
+	}
+	{
+		value, err := provider.Get(nil, `{"a":{"b":1}, "c":1}`, `{"a":1, "c":1, "d":2}`, "paths")
+		assert.Nil(t, err)
+		assert.EqualValues(t, []string{"a", "d"}, value)
+	}
+	{
+		_, err := provider.Get(nil, `{"a":1`, `{}`)
+		assert.NotNil(t, err)
+	}
+	{
+		_, err := provider.Get(nil, `{}`)
+		assert.NotNil(t, err)
+	}
+	{
+		_, err := provider.Get(nil, `{}`, `{}`, "abc")
+		assert.NotNil(t, err)
+	}
+}
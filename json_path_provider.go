@@ -0,0 +1,61 @@
+package toolbox
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+type jsonPathProvider struct{}
+
+//jsonPathToDotted converts bracketed path (i.e. user.addresses[0].city) into dotted one (user.addresses.0.city)
+func jsonPathToDotted(path string) string {
+	path = strings.Replace(path, "[", ".", -1)
+	path = strings.Replace(path, "]", "", -1)
+	return strings.TrimPrefix(path, ".")
+}
+
+//normalizeJSONValue converts json.Number into int or float64, nested maps and slices are converted in place
+func normalizeJSONValue(value interface{}) interface{} {
+	switch actual := value.(type) {
+	case json.Number:
+		if intValue, err := ToInt(actual.String()); err == nil && !strings.ContainsAny(actual.String(), ".eE") {
+			return intValue
+		}
+		return AsFloat(actual.String())
+	case map[string]interface{}:
+		for key, item := range actual {
+			actual[key] = normalizeJSONValue(item)
+		}
+	case []interface{}:
+		for i, item := range actual {
+			actual[i] = normalizeJSONValue(item)
+		}
+	}
+	return value
+}
+
+func (p jsonPathProvider) Get(context Context, arguments ...interface{}) (interface{}, error) {
+	if len(arguments) < 2 {
+		return nil, fmt.Errorf("expected 2 arguments (JSON, path) but had: %v", len(arguments))
+	}
+	var document interface{}
+	var JSON = AsString(arguments[0])
+	if err := NewJSONDecoderFactoryWithOption(true).Create(strings.NewReader(JSON)).Decode(&document); err != nil {
+		if len(JSON) > castedSnippetMaxLength {
+			JSON = JSON[:castedSnippetMaxLength] + "..."
+		}
+		return nil, fmt.Errorf("failed to decode JSON %v due to %v", JSON, err)
+	}
+	value, found := varProvider{}.lookup(document, jsonPathToDotted(AsString(arguments[1])))
+	if !found {
+		return nil, nil
+	}
+	return normalizeJSONValue(value), nil
+}
+
+//NewJSONPathProvider returns a provider that extracts value from JSON passed as the first argument with dotted or bracketed path passed as the second one,
+//i.e. user.addresses[0].city, numbers are returned as int or float64, missing path returns nil.
+func NewJSONPathProvider() ValueProvider {
+	return &jsonPathProvider{}
+}
@@ -0,0 +1,47 @@
+package toolbox_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/toolbox"
+)
+
+func TestNewJSONPathProvider(t *testing.T) {
+	provider := toolbox.NewJSONPathProvider()
+	var document = `{"user":{"name":"Bob","age":33,"score":4.5,"active":true,"addresses":[{"city":"Austin"},{"city":"Dallas","zip":75201}]}}`
+	var useCases = []struct {
+		path     string
+		expected interface{}
+	}{
+		{"user.name", "Bob"},
+		{"user.age", 33},
+		{"user.score", 4.5},
+		{"user.active", true},
+		{"user.addresses[0].city", "Austin"},
+		{"user.addresses[1].zip", 75201},
+		{"user.addresses.1.city", "Dallas"},
+		{"user.addresses[1]", map[string]interface{}{"city": "Dallas", "zip": 75201}},
+		{"user.missing", nil},
+		{"user.addresses[5].city", nil},
+		{"user.name.first", nil},
+	}
+	for _, useCase := range useCases {
+		value, err := provider.Get(nil, document, useCase.path)
+		assert.Nil(t, err, useCase.path)
+		assert.Equal(t, useCase.expected, value, useCase.path)
+	}
+	{
+		value, err := provider.Get(nil, []byte(`[{"id":1}]`), "[0].id")
+		assert.Nil(t, err)
+		assert.Equal(t, 1, value)
+	}
+	{
+		_, err := provider.Get(nil, `{"user":`, "user")
+		assert.NotNil(t, err)
+	}
+	{
+		_, err := provider.Get(nil, document)
+		assert.NotNil(t, err)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"os"
 	"strings"
 )
 
@@ -111,8 +112,8 @@ func (e *MacroEvaluator) Expand(context Context, input string) (interface{}, err
 		return input, nil
 	}
 
-	valueProviderRegistry := e.ValueProviderRegistry
-	if !valueProviderRegistry.Contains(macroName) {
+	valueProvider, ok := e.ValueProviderRegistry.Lookup(macroName)
+	if !ok {
 		return nil, fmt.Errorf("failed to lookup macro: '%v' while processing: %v", macroName, input)
 	}
 	arguments, err := e.decodeArguments(context, macroArguments, macro)
@@ -120,7 +121,6 @@ func (e *MacroEvaluator) Expand(context Context, input string) (interface{}, err
 		return nil, fmt.Errorf("failed expand macro: %v due to %v", macro, err.Error())
 	}
 
-	valueProvider := valueProviderRegistry.Get(macroName)
 	value, err := valueProvider.Get(context, arguments...)
 	if err != nil {
 		return nil, err
@@ -162,3 +162,82 @@ func ExpandValue(macroEvaluator *MacroEvaluator, value string) (string, error) {
 	}
 	return value, nil
 }
+
+//expandToken resolves ${provider:arg1,arg2} token body, body without colon is treated as env variable name
+func expandToken(token string, registry ValueProviderRegistry, context Context) (string, error) {
+	var name, arguments = token, []interface{}{}
+	if index := strings.Index(token, ":"); index != -1 {
+		name = token[:index]
+		for _, argument := range strings.Split(token[index+1:], ",") {
+			arguments = append(arguments, strings.TrimSpace(argument))
+		}
+	} else {
+		name, arguments = "env", []interface{}{token}
+		if registry == nil || !registry.Contains(name) {
+			if value, found := os.LookupEnv(token); found {
+				return value, nil
+			}
+			return "", fmt.Errorf("failed to expand ${%v}: env variable is not defined", token)
+		}
+	}
+	if registry == nil {
+		return "", fmt.Errorf("failed to expand ${%v}: unknown provider: %v", token, name)
+	}
+	provider, ok := registry.Lookup(name)
+	if !ok {
+		return "", fmt.Errorf("failed to expand ${%v}: unknown provider: %v", token, name)
+	}
+	value, err := provider.Get(context, arguments...)
+	if err != nil {
+		return "", fmt.Errorf("failed to expand ${%v}: %v", token, err)
+	}
+	return AsString(value), nil
+}
+
+//ExpandText replaces ${provider:arg1,arg2} tokens in passed in text with values of registry providers, token without colon (i.e. ${HOME}) is resolved
+//with registered "env" provider or OS environment, nested tokens are expanded first and $$ emits a literal $.
+func ExpandText(text string, registry ValueProviderRegistry, context Context) (string, error) {
+	var result = make([]byte, 0, len(text))
+	for i := 0; i < len(text); i++ {
+		if text[i] != '$' || i+1 >= len(text) {
+			result = append(result, text[i])
+			continue
+		}
+		switch text[i+1] {
+		case '$':
+			result = append(result, '$')
+			i++
+			continue
+		case '{':
+		default:
+			result = append(result, text[i])
+			continue
+		}
+		var depth, end = 0, -1
+		for j := i + 1; j < len(text) && end == -1; j++ {
+			switch text[j] {
+			case '{':
+				depth++
+			case '}':
+				depth--
+				if depth == 0 {
+					end = j
+				}
+			}
+		}
+		if end == -1 {
+			return "", fmt.Errorf("failed to expand %v: unterminated token at position %v", text, i)
+		}
+		token, err := ExpandText(text[i+2:end], registry, context)
+		if err != nil {
+			return "", err
+		}
+		value, err := expandToken(token, registry, context)
+		if err != nil {
+			return "", err
+		}
+		result = append(result, value...)
+		i = end
+	}
+	return string(result), nil
+}
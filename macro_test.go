@@ -2,6 +2,7 @@ package toolbox_test
 
 import (
 	"fmt"
+	"os"
 	"testing"
 
 	"errors"
@@ -168,3 +169,50 @@ func TestExpandValue(t *testing.T) {
 	}
 
 }
+
+func TestExpandText(t *testing.T) {
+	os.Setenv("EXPAND_TEXT_HOME", "/home/test")
+	os.Setenv("EXPAND_TEXT_SUFFIX", "HOME")
+	defer os.Unsetenv("EXPAND_TEXT_HOME")
+	defer os.Unsetenv("EXPAND_TEXT_SUFFIX")
+
+	registry := toolbox.NewValueProviderRegistry()
+	registry.Register("env", toolbox.NewEnvValueProvider())
+	registry.Register("expr", toolbox.NewExprProvider())
+	registry.Register("default", toolbox.NewEnvValueProvider())
+
+	var useCases = []struct {
+		text     string
+		expected string
+	}{
+		{"${EXPAND_TEXT_HOME}/config", "/home/test/config"},
+		{"${env:EXPAND_TEXT_HOME}/config", "/home/test/config"},
+		{"${default:EXPAND_TEXT_MISSING, /tmp}/config", "/tmp/config"},
+		{"${env:EXPAND_TEXT_${EXPAND_TEXT_SUFFIX}}", "/home/test"},
+		{"port: ${expr:8000 + 80}", "port: 8080"},
+		{"price: $$5, $${EXPAND_TEXT_HOME}", "price: $5, ${EXPAND_TEXT_HOME}"},
+		{"cost $5 {not a token}", "cost $5 {not a token}"},
+		{"trailing $", "trailing $"},
+	}
+	for _, useCase := range useCases {
+		expanded, err := toolbox.ExpandText(useCase.text, registry, nil)
+		assert.Nil(t, err, useCase.text)
+		assert.Equal(t, useCase.expected, expanded, useCase.text)
+	}
+
+	{ //without registry env variables are resolved from OS
+		expanded, err := toolbox.ExpandText("${EXPAND_TEXT_HOME}", nil, nil)
+		assert.Nil(t, err)
+		assert.Equal(t, "/home/test", expanded)
+	}
+	for _, text := range []string{"${unknown:abc}", "${EXPAND_TEXT_MISSING}", "${env:EXPAND_TEXT_HOME", "${expr:1/0}"} {
+		_, err := toolbox.ExpandText(text, registry, nil)
+		assert.NotNil(t, err, text)
+	}
+	{
+		_, err := toolbox.ExpandText("${unknown:abc}", registry, nil)
+		if assert.NotNil(t, err) {
+			assert.Contains(t, err.Error(), "unknown")
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package toolbox
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+//Outline represents hierarchical section counters
+type Outline []int
+
+//String returns dotted section number, i.e. 2.3.1
+func (o Outline) String() string {
+	var result = make([]string, len(o))
+	for i, counter := range o {
+		result[i] = AsString(counter)
+	}
+	return strings.Join(result, ".")
+}
+
+type outlineProvider struct {
+	outlineContextKey interface{}
+}
+
+func (p outlineProvider) outline(context Context) Outline {
+	var value = context.GetOptional(p.outlineContextKey)
+	if value == nil {
+		return Outline{}
+	}
+	var outlineValue = reflect.ValueOf(value)
+	if outlineValue.Kind() == reflect.Ptr {
+		outlineValue = outlineValue.Elem()
+	}
+	return outlineValue.Convert(reflect.TypeOf(Outline{})).Interface().(Outline)
+}
+
+func (p outlineProvider) Get(context Context, arguments ...interface{}) (interface{}, error) {
+	if context == nil {
+		return nil, fmt.Errorf("context was nil")
+	}
+	if len(arguments) == 0 {
+		return nil, fmt.Errorf("expected level or reset argument but had 0")
+	}
+	if strings.ToLower(AsString(arguments[0])) == "reset" {
+		return "", context.Replace(p.outlineContextKey, &Outline{})
+	}
+	level, err := ToInt(arguments[0])
+	if err != nil || level < 1 {
+		return nil, fmt.Errorf("invalid outline level: %v", arguments[0])
+	}
+	var outline = p.outline(context)
+	for len(outline) < level {
+		outline = append(outline, 0)
+	}
+	outline = append(Outline{}, outline[:level]...)
+	outline[level-1]++
+	if err = context.Replace(p.outlineContextKey, &outline); err != nil {
+		return nil, err
+	}
+	return outline.String(), nil
+}
+
+//NewOutlineProvider creates a new outline provider, it takes a context key that is an Outline pointer to keep state,
+//each call bumps counter at passed in level (first argument), resets deeper levels and returns dotted number, "reset" argument clears the outline.
+func NewOutlineProvider(contextKey interface{}) ValueProvider {
+	return &outlineProvider{contextKey}
+}
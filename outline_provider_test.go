@@ -0,0 +1,55 @@
+package toolbox_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/toolbox"
+)
+
+type appendixOutline toolbox.Outline
+
+func TestNewOutlineProvider(t *testing.T) {
+	var key *toolbox.Outline
+	provider := toolbox.NewOutlineProvider(key)
+	context := toolbox.NewContext()
+
+	for _, useCase := range []struct {
+		level    interface{}
+		expected string
+	}{
+		{1, "1"},
+		{2, "1.1"},
+		{2, "1.2"},
+		{"3", "1.2.1"},
+		{1, "2"},
+		{2, "2.1"},
+		{4, "2.1.0.1"},
+		{"reset", ""},
+		{2, "0.1"},
+		{1, "1"},
+	} {
+		value, err := provider.Get(context, useCase.level)
+		if assert.Nil(t, err) {
+			assert.Equal(t, useCase.expected, value)
+		}
+	}
+	{
+		var appendixKey *appendixOutline
+		appendixProvider := toolbox.NewOutlineProvider(appendixKey)
+		value, err := appendixProvider.Get(context, 1)
+		assert.Nil(t, err)
+		assert.Equal(t, "1", value)
+		value, err = provider.Get(context, 1)
+		assert.Nil(t, err)
+		assert.Equal(t, "2", value)
+	}
+	{
+		_, err := provider.Get(context, 0)
+		assert.NotNil(t, err)
+	}
+	{
+		_, err := provider.Get(nil, 1)
+		assert.NotNil(t, err)
+	}
+}
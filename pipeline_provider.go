@@ -0,0 +1,50 @@
+package toolbox
+
+import "fmt"
+
+type pipelineProvider struct {
+	providers []ValueProvider
+}
+
+func (p pipelineProvider) Get(context Context, arguments ...interface{}) (interface{}, error) {
+	if len(p.providers) == 0 {
+		return nil, fmt.Errorf("pipeline has no providers")
+	}
+	var result interface{}
+	for i, provider := range p.providers {
+		var stageArguments = arguments
+		if i > 0 {
+			stageArguments = []interface{}{result}
+		}
+		value, err := provider.Get(context, stageArguments...)
+		if err != nil {
+			return nil, fmt.Errorf("pipeline stage %v failed: %v", i, err)
+		}
+		result = value
+	}
+	return result, nil
+}
+
+//NewPipelineProvider returns a provider that calls supplied providers in order with the same context, the first provider receives pipeline arguments,
+//each next one receives the previous result as its only argument. Processing stops at the first error, which is reported with its stage index.
+func NewPipelineProvider(providers ...ValueProvider) ValueProvider {
+	return &pipelineProvider{providers: providers}
+}
+
+type partialProvider struct {
+	provider  ValueProvider
+	arguments []interface{}
+}
+
+func (p partialProvider) Get(context Context, arguments ...interface{}) (interface{}, error) {
+	var providerArguments = make([]interface{}, 0, len(p.arguments)+len(arguments))
+	providerArguments = append(providerArguments, p.arguments...)
+	providerArguments = append(providerArguments, arguments...)
+	return p.provider.Get(context, providerArguments...)
+}
+
+//NewPartialProvider returns a provider that calls supplied provider with fixed arguments followed by passed in arguments,
+//i.e. NewPartialProvider(NewCastedValueProvider(), "int") casts its argument to int, which makes it usable as a pipeline stage.
+func NewPartialProvider(provider ValueProvider, arguments ...interface{}) ValueProvider {
+	return &partialProvider{provider: provider, arguments: arguments}
+}
@@ -0,0 +1,49 @@
+package toolbox_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/toolbox"
+)
+
+func TestNewPipelineProvider(t *testing.T) {
+	os.Setenv("PIPELINE_TEST_PORT", "8080")
+	defer os.Unsetenv("PIPELINE_TEST_PORT")
+
+	provider := toolbox.NewPipelineProvider(
+		toolbox.NewEnvValueProvider(),
+		toolbox.NewPartialProvider(toolbox.NewCastedValueProvider(), "int"),
+	)
+	{
+		value, err := provider.Get(nil, "PIPELINE_TEST_PORT")
+		assert.Nil(t, err)
+		assert.Equal(t, 8080, value)
+	}
+	{
+		value, err := provider.Get(nil, "PIPELINE_TEST_MISSING", "9090")
+		assert.Nil(t, err)
+		assert.Equal(t, 9090, value)
+	}
+	{
+		_, err := provider.Get(nil, "PIPELINE_TEST_MISSING")
+		if assert.NotNil(t, err) {
+			assert.Contains(t, err.Error(), "stage 0")
+		}
+	}
+	{
+		provider := toolbox.NewPipelineProvider(
+			toolbox.NewEnvValueProvider(),
+			toolbox.NewPartialProvider(toolbox.NewCastedValueProvider(), "unknown"),
+		)
+		_, err := provider.Get(nil, "PIPELINE_TEST_PORT")
+		if assert.NotNil(t, err) {
+			assert.Contains(t, err.Error(), "stage 1")
+		}
+	}
+	{
+		_, err := toolbox.NewPipelineProvider().Get(nil)
+		assert.NotNil(t, err)
+	}
+}
@@ -0,0 +1,119 @@
+package toolbox
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+)
+
+//DefaultAverageChunkSize represents default average content defined chunk size
+var DefaultAverageChunkSize = 8192
+
+var gearTable = newGearTable()
+
+//newGearTable returns deterministic pseudo random table used by gear rolling hash
+func newGearTable() [256]uint64 {
+	var result [256]uint64
+	var seed uint64 = 0x9E3779B97F4A7C15
+	for i := range result {
+		seed += 0x9E3779B97F4A7C15
+		value := seed
+		value = (value ^ (value >> 30)) * 0xBF58476D1CE4E5B9
+		value = (value ^ (value >> 27)) * 0x94D049BB133111EB
+		result[i] = value ^ (value >> 31)
+	}
+	return result
+}
+
+type rollingHashProvider struct{}
+
+func (p rollingHashProvider) open(source interface{}) (io.Reader, func() error, error) {
+	var noop = func() error { return nil }
+	switch value := source.(type) {
+	case []byte:
+		return bytes.NewReader(value), noop, nil
+	case io.Reader:
+		return value, noop, nil
+	}
+	var text = AsString(source)
+	if strings.HasPrefix(text, "file:") {
+		reader, _, err := OpenReaderFromURL(text)
+		if err != nil {
+			return nil, nil, err
+		}
+		return reader, reader.Close, nil
+	}
+	return strings.NewReader(text), noop, nil
+}
+
+func (p rollingHashProvider) Get(context Context, arguments ...interface{}) (interface{}, error) {
+	if len(arguments) == 0 {
+		return nil, fmt.Errorf("expected at least one argument but had 0")
+	}
+	var averageSize = DefaultAverageChunkSize
+	if len(arguments) > 1 {
+		averageSize = AsInt(arguments[1])
+	}
+	if averageSize < 64 {
+		return nil, fmt.Errorf("invalid average chunk size: %v, expected at least 64", averageSize)
+	}
+	var maskBits uint
+	for 1<<(maskBits+1) <= averageSize {
+		maskBits++
+	}
+	var mask = uint64(1<<maskBits-1) << (64 - maskBits)
+	var minSize, maxSize = averageSize / 4, averageSize * 4
+
+	reader, closer, err := p.open(arguments[0])
+	if err != nil {
+		return nil, err
+	}
+	defer closer()
+
+	var result = make([]interface{}, 0)
+	var bufferedReader = bufio.NewReader(reader)
+	var chunkHash = sha256.New()
+	var offset, size = 0, 0
+	var rollingHash uint64
+	var flush = func() {
+		result = append(result, map[string]interface{}{
+			"offset": offset,
+			"size":   size,
+			"hash":   hex.EncodeToString(chunkHash.Sum(nil)),
+		})
+		offset += size
+		size = 0
+		rollingHash = 0
+		chunkHash.Reset()
+	}
+	for {
+		aByte, err := bufferedReader.ReadByte()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		chunkHash.Write([]byte{aByte})
+		size++
+		rollingHash = (rollingHash << 1) + gearTable[aByte]
+		if size >= maxSize || (size >= minSize && rollingHash&mask == 0) {
+			flush()
+		}
+	}
+	if size > 0 {
+		flush()
+	}
+	return result, nil
+}
+
+//NewRollingHashProvider returns a provider that computes content defined chunks with gear rolling hash over bytes, string or file: URL content (first argument),
+//optional second argument specifies average chunk size (DefaultAverageChunkSize by default). Result is a slice of maps with offset, size and SHA-256 hash of each chunk.
+func NewRollingHashProvider() ValueProvider {
+	var result ValueProvider = &rollingHashProvider{}
+	return result
+}
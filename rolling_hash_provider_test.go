@@ -0,0 +1,67 @@
+package toolbox_test
+
+import (
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/toolbox"
+)
+
+func TestNewRollingHashProvider(t *testing.T) {
+	provider := toolbox.NewRollingHashProvider()
+	var data = make([]byte, 256*1024)
+	rand.New(rand.NewSource(1)).Read(data)
+
+	value, err := provider.Get(nil, data, 4096)
+	if !assert.Nil(t, err) {
+		return
+	}
+	chunks := toolbox.AsSlice(value)
+	assert.True(t, len(chunks) > 16)
+	var offset = 0
+	var hashes = make(map[string]bool)
+	for _, item := range chunks {
+		chunk := toolbox.AsMap(item)
+		assert.Equal(t, offset, chunk["offset"])
+		assert.True(t, toolbox.AsInt(chunk["size"]) <= 4*4096)
+		offset += toolbox.AsInt(chunk["size"])
+		hashes[toolbox.AsString(chunk["hash"])] = true
+	}
+	assert.Equal(t, len(data), offset)
+
+	{
+		//content defined boundaries are preserved when data is prepended
+		var filename = path.Join(os.TempDir(), "rolling_hash_provider_test.bin")
+		err := ioutil.WriteFile(filename, append([]byte("prefix"), data...), 0644)
+		assert.Nil(t, err)
+		defer os.Remove(filename)
+
+		value, err := provider.Get(nil, "file://"+filename, 4096)
+		assert.Nil(t, err)
+		var shared = 0
+		for _, item := range toolbox.AsSlice(value) {
+			if hashes[toolbox.AsString(toolbox.AsMap(item)["hash"])] {
+				shared++
+			}
+		}
+		assert.True(t, shared >= len(chunks)-2)
+	}
+	{
+		repeated, err := provider.Get(nil, data, 4096)
+		assert.Nil(t, err)
+		assert.EqualValues(t, value, repeated)
+	}
+	{
+		value, err := provider.Get(nil, "")
+		assert.Nil(t, err)
+		assert.Equal(t, 0, len(toolbox.AsSlice(value)))
+	}
+	{
+		_, err := provider.Get(nil, data, 10)
+		assert.NotNil(t, err)
+	}
+}
@@ -0,0 +1,49 @@
+package toolbox
+
+import (
+	"fmt"
+	"reflect"
+)
+
+//Sequence represents a counter state
+type Sequence int
+
+type sequenceProvider struct {
+	sequenceContextKey interface{}
+}
+
+func (p sequenceProvider) Get(context Context, arguments ...interface{}) (interface{}, error) {
+	if context == nil {
+		return nil, fmt.Errorf("context was nil")
+	}
+	var start, step = 1, 1
+	var err error
+	if len(arguments) > 0 {
+		if start, err = ToInt(arguments[0]); err != nil {
+			return nil, fmt.Errorf("invalid sequence start %v: %v", arguments[0], err)
+		}
+	}
+	if len(arguments) > 1 {
+		if step, err = ToInt(arguments[1]); err != nil {
+			return nil, fmt.Errorf("invalid sequence step %v: %v", arguments[1], err)
+		}
+	}
+	var next = Sequence(start)
+	if value := context.GetOptional(p.sequenceContextKey); value != nil {
+		var sequenceValue = reflect.ValueOf(value)
+		if sequenceValue.Kind() == reflect.Ptr {
+			sequenceValue = sequenceValue.Elem()
+		}
+		next = sequenceValue.Convert(reflect.TypeOf(next)).Interface().(Sequence) + Sequence(step)
+	}
+	if err = context.Replace(p.sequenceContextKey, &next); err != nil {
+		return nil, err
+	}
+	return int(next), nil
+}
+
+//NewSequenceProvider creates a new sequence provider, it takes a context key that is a Sequence pointer to keep counter state,
+//the first call returns start value (first optional argument, 1 by default), subsequent calls increment counter by step (second optional argument, 1 by default).
+func NewSequenceProvider(contextKey interface{}) ValueProvider {
+	return &sequenceProvider{contextKey}
+}
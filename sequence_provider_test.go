@@ -0,0 +1,48 @@
+package toolbox_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/toolbox"
+)
+
+type orderSequence toolbox.Sequence
+
+func TestNewSequenceProvider(t *testing.T) {
+	context := toolbox.NewContext()
+
+	var key *toolbox.Sequence
+	provider := toolbox.NewSequenceProvider(key)
+	for _, expected := range []int{1, 2, 3} {
+		value, err := provider.Get(context)
+		assert.Nil(t, err)
+		assert.Equal(t, expected, value)
+	}
+
+	var orderKey *orderSequence
+	orderProvider := toolbox.NewSequenceProvider(orderKey)
+	for _, expected := range []int{100, 110, 120} {
+		value, err := orderProvider.Get(context, 100, 10)
+		assert.Nil(t, err)
+		assert.Equal(t, expected, value)
+	}
+	{
+		value, err := provider.Get(context)
+		assert.Nil(t, err)
+		assert.Equal(t, 4, value)
+	}
+	{
+		value, err := provider.Get(toolbox.NewContext(), "5", "-1")
+		assert.Nil(t, err)
+		assert.Equal(t, 5, value)
+	}
+	{
+		_, err := provider.Get(context, "abc")
+		assert.NotNil(t, err)
+	}
+	{
+		_, err := provider.Get(nil)
+		assert.NotNil(t, err)
+	}
+}
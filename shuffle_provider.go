@@ -0,0 +1,42 @@
+package toolbox
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"time"
+)
+
+type shuffleProvider struct{}
+
+func (p shuffleProvider) Get(context Context, arguments ...interface{}) (interface{}, error) {
+	if len(arguments) == 0 {
+		return nil, fmt.Errorf("expected at least one argument but had 0")
+	}
+	if arguments[0] == nil || !IsSlice(arguments[0]) {
+		return nil, fmt.Errorf("expected slice but had %T", arguments[0])
+	}
+	var seed = time.Now().UnixNano()
+	if len(arguments) > 1 {
+		seedValue, err := ToInt(arguments[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid seed %v: %v", arguments[1], err)
+		}
+		seed = int64(seedValue)
+	}
+	sliceValue := DiscoverValueByKind(arguments[0], reflect.Slice)
+	result := reflect.MakeSlice(sliceValue.Type(), sliceValue.Len(), sliceValue.Len())
+	reflect.Copy(result, sliceValue)
+	var random = rand.New(rand.NewSource(seed))
+	swapper := reflect.Swapper(result.Interface())
+	for i := result.Len() - 1; i > 0; i-- {
+		swapper(i, random.Intn(i+1))
+	}
+	return result.Interface(), nil
+}
+
+//NewShuffleProvider returns a provider that returns shuffled copy of a slice (first argument), optional seed (second argument) makes shuffle deterministic.
+func NewShuffleProvider() ValueProvider {
+	var result ValueProvider = &shuffleProvider{}
+	return result
+}
@@ -0,0 +1,47 @@
+package toolbox_test
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/toolbox"
+)
+
+func TestNewShuffleProvider(t *testing.T) {
+	provider := toolbox.NewShuffleProvider()
+	var source = []string{"a", "b", "c", "d", "e", "f", "g", "h"}
+	var original = append([]string{}, source...)
+	{
+		value, err := provider.Get(nil, source, 42)
+		assert.Nil(t, err)
+		shuffled, ok := value.([]string)
+		if assert.True(t, ok) {
+			assert.EqualValues(t, original, source)
+			assert.NotEqual(t, source, shuffled)
+			repeated, err := provider.Get(nil, source, "42")
+			assert.Nil(t, err)
+			assert.EqualValues(t, shuffled, repeated)
+			sort.Strings(shuffled)
+			assert.EqualValues(t, original, shuffled)
+		}
+	}
+	{
+		value, err := provider.Get(nil, []interface{}{1, 2, 3})
+		assert.Nil(t, err)
+		assert.Equal(t, 3, len(toolbox.AsSlice(value)))
+	}
+	{
+		value, err := provider.Get(nil, []int{}, 1)
+		assert.Nil(t, err)
+		assert.EqualValues(t, []int{}, value)
+	}
+	{
+		_, err := provider.Get(nil, "abc")
+		assert.NotNil(t, err)
+	}
+	{
+		_, err := provider.Get(nil, source, "abc")
+		assert.NotNil(t, err)
+	}
+}
@@ -0,0 +1,33 @@
+package toolbox
+
+import (
+	"fmt"
+	"strings"
+)
+
+type splitProvider struct{}
+
+func (p splitProvider) Get(context Context, arguments ...interface{}) (interface{}, error) {
+	if len(arguments) < 2 {
+		return nil, fmt.Errorf("expected at least 2 arguments but had %v", len(arguments))
+	}
+	var text = AsString(arguments[0])
+	var separator = AsString(arguments[1])
+	var trim = len(arguments) > 2 && AsBoolean(arguments[2])
+	if trim && strings.TrimSpace(text) == "" {
+		return []string{}, nil
+	}
+	var result = strings.Split(text, separator)
+	if trim {
+		for i, part := range result {
+			result[i] = strings.TrimSpace(part)
+		}
+	}
+	return result, nil
+}
+
+//NewSplitProvider returns a provider that splits text (first argument) on separator (second argument), optional third argument trims whitespace on each part
+func NewSplitProvider() ValueProvider {
+	var result ValueProvider = &splitProvider{}
+	return result
+}
@@ -0,0 +1,35 @@
+package toolbox_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/toolbox"
+)
+
+func TestNewSplitProvider(t *testing.T) {
+	provider := toolbox.NewSplitProvider()
+	var useCases = []struct {
+		arguments []interface{}
+		expected  []string
+	}{
+		{[]interface{}{"a,b,c", ","}, []string{"a", "b", "c"}},
+		{[]interface{}{" a , b ,c ", ","}, []string{" a ", " b ", "c "}},
+		{[]interface{}{" a , b ,c ", ",", true}, []string{"a", "b", "c"}},
+		{[]interface{}{" a , b ,c ", ",", "true"}, []string{"a", "b", "c"}},
+		{[]interface{}{"", ","}, []string{""}},
+		{[]interface{}{"", ",", true}, []string{}},
+		{[]interface{}{"   ", ",", true}, []string{}},
+		{[]interface{}{"one::two:three::", "::"}, []string{"one", "two:three", ""}},
+		{[]interface{}{"k1 => v1 =>  k2", "=>", true}, []string{"k1", "v1", "k2"}},
+	}
+	for _, useCase := range useCases {
+		value, err := provider.Get(nil, useCase.arguments...)
+		assert.Nil(t, err)
+		assert.Equal(t, useCase.expected, value, useCase.arguments[0])
+	}
+	{
+		_, err := provider.Get(nil, "a,b")
+		assert.NotNil(t, err)
+	}
+}
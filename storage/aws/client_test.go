@@ -0,0 +1,115 @@
+package aws
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/toolbox/storage"
+)
+
+type pagedListClient struct {
+	s3iface.S3API
+	pages []*s3.ListObjectsOutput
+}
+
+func (c *pagedListClient) ListObjectsPages(input *s3.ListObjectsInput, fn func(*s3.ListObjectsOutput, bool) bool) error {
+	for i, page := range c.pages {
+		if !fn(page, i+1 == len(c.pages)) {
+			break
+		}
+	}
+	return nil
+}
+
+func TestList_Pagination(t *testing.T) {
+	var modified = time.Now()
+	var newContent = func(key string) *s3.Object {
+		return &s3.Object{Key: aws.String(key), Size: aws.Int64(3), LastModified: &modified}
+	}
+	client := &pagedListClient{
+		pages: []*s3.ListObjectsOutput{
+			{
+				CommonPrefixes: []*s3.CommonPrefix{{Prefix: aws.String("data/folder1/")}},
+				Contents:       []*s3.Object{newContent("data/file1.csv")},
+			},
+			{
+				Contents: []*s3.Object{newContent("data/file2.csv")},
+			},
+			{
+				CommonPrefixes: []*s3.CommonPrefix{{Prefix: aws.String("data/folder2/")}},
+				Contents:       []*s3.Object{newContent("data/file3.csv")},
+			},
+		},
+	}
+	parsedURL, _ := url.Parse("s3://bucket/data/")
+	var result = make([]storage.Object, 0)
+	err := listFolders(client, parsedURL, &result)
+	assert.Nil(t, err)
+	err = listContent(client, parsedURL, &result)
+	assert.Nil(t, err)
+
+	var actual = make(map[string]bool)
+	for _, object := range result {
+		actual[object.URL()] = object.IsFolder()
+	}
+	assert.EqualValues(t, map[string]bool{
+		"s3://bucket/data/folder1/":  true,
+		"s3://bucket/data/folder2/":  true,
+		"s3://bucket/data/file1.csv": false,
+		"s3://bucket/data/file2.csv": false,
+		"s3://bucket/data/file3.csv": false,
+	}, actual)
+}
+
+type batchDeleteClient struct {
+	s3iface.S3API
+	requests []*s3.DeleteObjectsInput
+}
+
+func (c *batchDeleteClient) DeleteObjects(input *s3.DeleteObjectsInput) (*s3.DeleteObjectsOutput, error) {
+	c.requests = append(c.requests, input)
+	var output = &s3.DeleteObjectsOutput{}
+	for _, identifier := range input.Delete.Objects {
+		if strings.HasPrefix(*identifier.Key, "locked/") {
+			output.Errors = append(output.Errors, &s3.Error{Key: identifier.Key, Message: aws.String("Access Denied")})
+		}
+	}
+	return output, nil
+}
+
+func TestDeleteObjects(t *testing.T) {
+	var newObject = func(URL string) storage.Object {
+		var fileInfo = storage.NewFileInfo("file", 1, 0644, time.Now(), false)
+		return newStorageObject(URL, nil, fileInfo)
+	}
+	var objects = make([]storage.Object, 0)
+	for i := 0; i < 1001; i++ {
+		objects = append(objects, newObject(fmt.Sprintf("s3://bucket1/data/%v.csv", i)))
+	}
+	objects = append(objects, newObject("s3://bucket2/locked/file.csv"), newObject("s3://bucket2/data/file.csv"))
+
+	client := &batchDeleteClient{}
+	err := deleteObjects(client, objects)
+	if assert.NotNil(t, err) {
+		assert.True(t, strings.Contains(err.Error(), "s3://bucket2/locked/file.csv: Access Denied"))
+		assert.False(t, strings.Contains(err.Error(), "s3://bucket2/data/file.csv"))
+	}
+	if assert.Equal(t, 3, len(client.requests)) {
+		assert.Equal(t, "bucket1", *client.requests[0].Bucket)
+		assert.Equal(t, 1000, len(client.requests[0].Delete.Objects))
+		assert.Equal(t, "data/0.csv", *client.requests[0].Delete.Objects[0].Key)
+		assert.Equal(t, 1, len(client.requests[1].Delete.Objects))
+		assert.Equal(t, "bucket2", *client.requests[2].Bucket)
+		assert.Equal(t, 2, len(client.requests[2].Delete.Objects))
+	}
+
+	client = &batchDeleteClient{}
+	assert.Nil(t, deleteObjects(client, objects[:10]))
+}
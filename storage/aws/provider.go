@@ -1,6 +1,7 @@
 package aws
 
 import (
+	"fmt"
 	"github.com/viant/toolbox"
 	"github.com/viant/toolbox/storage"
 	"os"
@@ -13,6 +14,7 @@ const ProviderScheme = "s3"
 
 func init() {
 	storage.NewStorageProvider().Registry[ProviderScheme] = serviceProvider
+	storage.NewStorageProvider().ConfigRegistry[ProviderScheme] = serviceConfigProvider
 }
 
 func serviceProvider(credentialFile string) (storage.Service, error) {
@@ -27,3 +29,24 @@ func serviceProvider(credentialFile string) (storage.Service, error) {
 	}
 	return NewService(s3config), nil
 }
+
+//serviceConfigProvider creates a service for *Config, Config or map config
+func serviceConfigProvider(config interface{}) (storage.Service, error) {
+	var s3config = &Config{}
+	switch value := config.(type) {
+	case nil:
+		return nil, fmt.Errorf("s3 config was empty")
+	case *Config:
+		s3config = value
+	case Config:
+		s3config = &value
+	default:
+		if !toolbox.IsMap(config) {
+			return nil, fmt.Errorf("unsupported s3 config type: %T", config)
+		}
+		if err := toolbox.MapToStruct(toolbox.AsMap(config), s3config, ""); err != nil {
+			return nil, err
+		}
+	}
+	return NewService(s3config), nil
+}
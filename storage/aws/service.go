@@ -2,6 +2,7 @@ package aws
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"net/url"
@@ -11,6 +12,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
 	"github.com/aws/aws-sdk-go/service/s3/s3manager"
 	"github.com/viant/toolbox"
 	"github.com/viant/toolbox/storage"
@@ -23,7 +25,7 @@ type service struct {
 	config *Config
 }
 
-func listFolders(client *s3.S3, url *url.URL, result *[]storage.Object) error {
+func listFolders(client s3iface.S3API, url *url.URL, result *[]storage.Object) error {
 	folderRequest := &s3.ListObjectsInput{
 		Bucket:    aws.String(url.Host),
 		Prefix:    aws.String(url.Path[1:]),
@@ -33,7 +35,7 @@ func listFolders(client *s3.S3, url *url.URL, result *[]storage.Object) error {
 	err := client.ListObjectsPages(folderRequest,
 		func(page *s3.ListObjectsOutput, lastPage bool) bool {
 			prefixes = append(prefixes, page.CommonPrefixes...)
-			return len(page.CommonPrefixes) > 0
+			return true
 		})
 
 	if err != nil {
@@ -53,7 +55,7 @@ func listFolders(client *s3.S3, url *url.URL, result *[]storage.Object) error {
 	return nil
 }
 
-func listContent(client *s3.S3, parsedURL *url.URL, result *[]storage.Object) error {
+func listContent(client s3iface.S3API, parsedURL *url.URL, result *[]storage.Object) error {
 	var path = parsedURL.Path
 
 	folderRequest := &s3.ListObjectsInput{
@@ -67,7 +69,7 @@ func listContent(client *s3.S3, parsedURL *url.URL, result *[]storage.Object) er
 	err := client.ListObjectsPages(folderRequest,
 		func(page *s3.ListObjectsOutput, lastPage bool) bool {
 			contents = append(contents, page.Contents...)
-			return len(page.Contents) > 0
+			return true
 		})
 
 	if err != nil {
@@ -125,6 +127,20 @@ func (s *service) Exists(URL string) (bool, error) {
 	return len(objects) > 0, nil
 }
 
+//ExistsPrefix returns true if at least one object URL starts with supplied URL, it uses native prefix listing
+func (s *service) ExistsPrefix(URL string) (bool, error) {
+	objects, err := s.List(URL)
+	if err != nil {
+		return false, err
+	}
+	for _, object := range objects {
+		if strings.HasPrefix(strings.TrimSuffix(object.URL(), "/"), URL) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 func (s *service) StorageObject(URL string) (storage.Object, error) {
 	objects, err := s.List(URL)
 	if err != nil {
@@ -136,7 +152,13 @@ func (s *service) StorageObject(URL string) (storage.Object, error) {
 	return objects[0], nil
 }
 
+//Download returns reader for downloaded storage object
 func (s *service) Download(object storage.Object) (io.Reader, error) {
+	return s.DownloadWithContext(context.Background(), object)
+}
+
+//DownloadWithContext returns reader for downloaded storage object, download is cancelled once context is done
+func (s *service) DownloadWithContext(ctx context.Context, object storage.Object) (io.Reader, error) {
 	u, err := url.Parse(object.URL())
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse : %v", err)
@@ -149,11 +171,14 @@ func (s *service) Download(object storage.Object) (io.Reader, error) {
 	target := &s3.Object{}
 	object.Unwrap(&target)
 	writer := toolbox.NewByteWriterAt()
-	_, err = downloader.Download(writer,
+	_, err = downloader.DownloadWithContext(ctx, writer,
 		&s3.GetObjectInput{
 			Bucket: aws.String(u.Host),
 			Key:    aws.String(*target.Key),
 		})
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return nil, ctxErr
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to download: %v", err)
 	}
@@ -161,7 +186,28 @@ func (s *service) Download(object storage.Object) (io.Reader, error) {
 
 }
 
+//CreateFolder is no-op as s3 folders are virtual, they exist as long as there is an object under folder key prefix
+func (s *service) CreateFolder(URL string) error {
+	return nil
+}
+
+//Upload uploads provided reader content for supplied url
 func (s *service) Upload(URL string, reader io.Reader) error {
+	return s.upload(context.Background(), URL, reader, storage.UploadOptions{})
+}
+
+//UploadWithContext uploads provided reader content for supplied url, upload is cancelled once context is done
+func (s *service) UploadWithContext(ctx context.Context, URL string, reader io.Reader) error {
+	return s.upload(ctx, URL, reader, storage.UploadOptions{})
+}
+
+//UploadWithOptions uploads provided reader content for supplied url with content type, cache control and metadata
+func (s *service) UploadWithOptions(URL string, reader io.Reader, options storage.UploadOptions) error {
+	URL, reader = storage.CompressedUpload(URL, reader, options)
+	return s.upload(context.Background(), URL, reader, options)
+}
+
+func (s *service) upload(ctx context.Context, URL string, reader io.Reader, options storage.UploadOptions) error {
 	parsedURL, err := url.Parse(URL)
 	if err != nil {
 		return err
@@ -171,11 +217,24 @@ func (s *service) Upload(URL string, reader io.Reader) error {
 		return err
 	}
 	uploader := s3manager.NewUploader(session.New(config))
-	_, err = uploader.Upload(&s3manager.UploadInput{
+	input := &s3manager.UploadInput{
 		Body:   reader,
 		Bucket: aws.String(parsedURL.Host),
 		Key:    aws.String(parsedURL.Path),
-	})
+	}
+	if options.ContentType != "" {
+		input.ContentType = aws.String(options.ContentType)
+	}
+	if options.CacheControl != "" {
+		input.CacheControl = aws.String(options.CacheControl)
+	}
+	if len(options.Metadata) > 0 {
+		input.Metadata = aws.StringMap(options.Metadata)
+	}
+	_, err = uploader.UploadWithContext(ctx, input)
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return ctxErr
+	}
 	if err != nil {
 		return fmt.Errorf("failed to upload %v", err)
 	}
@@ -202,6 +261,101 @@ func (s *service) Delete(object storage.Object) error {
 	return nil
 }
 
+//maxDeleteBatchSize represents max number of keys accepted by a single DeleteObjects request
+const maxDeleteBatchSize = 1000
+
+func deleteObjects(client s3iface.S3API, objects []storage.Object) error {
+	var failures = make([]string, 0)
+	var buckets = make([]string, 0)
+	var bucketKeys = make(map[string][]*s3.ObjectIdentifier)
+	for _, object := range objects {
+		parsedURL, err := url.Parse(object.URL())
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%v: %v", object.URL(), err))
+			continue
+		}
+		if _, ok := bucketKeys[parsedURL.Host]; !ok {
+			buckets = append(buckets, parsedURL.Host)
+		}
+		bucketKeys[parsedURL.Host] = append(bucketKeys[parsedURL.Host], &s3.ObjectIdentifier{Key: aws.String(strings.TrimPrefix(parsedURL.Path, "/"))})
+	}
+	for _, bucket := range buckets {
+		var keys = bucketKeys[bucket]
+		for i := 0; i < len(keys); i += maxDeleteBatchSize {
+			var end = i + maxDeleteBatchSize
+			if end > len(keys) {
+				end = len(keys)
+			}
+			output, err := client.DeleteObjects(&s3.DeleteObjectsInput{
+				Bucket: aws.String(bucket),
+				Delete: &s3.Delete{Objects: keys[i:end], Quiet: aws.Bool(true)},
+			})
+			if err != nil {
+				for _, key := range keys[i:end] {
+					failures = append(failures, fmt.Sprintf("s3://%v/%v: %v", bucket, aws.StringValue(key.Key), err))
+				}
+				continue
+			}
+			for _, deleteError := range output.Errors {
+				failures = append(failures, fmt.Sprintf("s3://%v/%v: %v", bucket, aws.StringValue(deleteError.Key), aws.StringValue(deleteError.Message)))
+			}
+		}
+	}
+	if len(failures) == 0 {
+		return nil
+	}
+	return fmt.Errorf("failed to delete %v object(s):\n\t%v", len(failures), strings.Join(failures, "\n\t"))
+}
+
+//DeleteAll removes passed in storage objects with batched DeleteObjects requests
+func (s *service) DeleteAll(objects []storage.Object) error {
+	config, err := s.getAwsConfig()
+	if err != nil {
+		return err
+	}
+	return deleteObjects(s3.New(session.New(), config), objects)
+}
+
+//ChecksumMD5 returns MD5 hex digest of storage object content
+func (s *service) ChecksumMD5(object storage.Object) (string, error) {
+	return storage.ChecksumMD5(s, object)
+}
+
+//ChecksumSHA256 returns SHA-256 hex digest of storage object content
+func (s *service) ChecksumSHA256(object storage.Object) (string, error) {
+	return storage.ChecksumSHA256(s, object)
+}
+
+//DownloadRange returns reader for downloaded storage object content window
+func (s *service) DownloadRange(object storage.Object, offset, length int64) (io.Reader, error) {
+	return storage.DownloadRange(s, object, offset, length)
+}
+
+//ListMatch returns objects for supplied url whose base name matches shell pattern
+func (s *service) ListMatch(URL, pattern string) ([]storage.Object, error) {
+	return storage.ListMatch(s, URL, pattern)
+}
+
+//ListRecursive returns all objects under supplied url
+func (s *service) ListRecursive(URL string) ([]storage.Object, error) {
+	return storage.ListRecursive(s, URL)
+}
+
+//Walk calls visitor with each object under supplied url
+func (s *service) Walk(URL string, visitor storage.WalkVisitor) error {
+	return storage.Walk(s, URL, visitor)
+}
+
+//Copy copies object from source URL to destination URL
+func (s *service) Copy(sourceURL, destinationURL string) error {
+	return storage.CopyObject(s, sourceURL, s, destinationURL)
+}
+
+//Move moves object from source URL to destination URL
+func (s *service) Move(sourceURL, destinationURL string) error {
+	return storage.MoveObject(s, sourceURL, s, destinationURL)
+}
+
 func (s *service) Register(schema string, service storage.Service) error {
 	return fmt.Errorf("Unsupported")
 }
@@ -0,0 +1,112 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+)
+
+//encryptedStorageService represents a storage service decorator that encrypts content at rest with AES-GCM.
+//Stored content layout: nonce (12 bytes) followed by ciphertext with appended 16 bytes GCM authentication tag.
+type encryptedStorageService struct {
+	Service
+	key []byte
+}
+
+func (s *encryptedStorageService) newCipher() (cipher.AEAD, error) {
+	block, err := aes.NewCipher(s.key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid encryption key: %v", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+//Download returns reader for downloaded and decrypted storage object
+func (s *encryptedStorageService) Download(object Object) (io.Reader, error) {
+	aead, err := s.newCipher()
+	if err != nil {
+		return nil, err
+	}
+	reader, err := s.Service.Download(object)
+	if err != nil {
+		return nil, err
+	}
+	content, err := ioutil.ReadAll(reader)
+	closeReader(reader)
+	if err != nil {
+		return nil, err
+	}
+	if len(content) < aead.NonceSize()+aead.Overhead() {
+		return nil, errors.New("failed to decrypt " + object.URL() + ": content too short")
+	}
+	var nonce, encrypted = content[:aead.NonceSize()], content[aead.NonceSize():]
+	decrypted, err := aead.Open(nil, nonce, encrypted, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt %v: %v", object.URL(), err)
+	}
+	return bytes.NewReader(decrypted), nil
+}
+
+//DownloadWithContext returns reader for downloaded storage object, transfer is aborted once context is done
+func (s *encryptedStorageService) DownloadWithContext(ctx context.Context, object Object) (io.Reader, error) {
+	return DownloadWithContext(ctx, s, object)
+}
+
+//UploadWithContext uploads provided reader content for supplied url, transfer is aborted once context is done
+func (s *encryptedStorageService) UploadWithContext(ctx context.Context, URL string, reader io.Reader) error {
+	return UploadWithContext(ctx, s, URL, reader)
+}
+
+//ChecksumMD5 returns MD5 hex digest of storage object content
+func (s *encryptedStorageService) ChecksumMD5(object Object) (string, error) {
+	return ChecksumMD5(s, object)
+}
+
+//ChecksumSHA256 returns SHA-256 hex digest of storage object content
+func (s *encryptedStorageService) ChecksumSHA256(object Object) (string, error) {
+	return ChecksumSHA256(s, object)
+}
+
+//DownloadRange returns reader for decrypted storage object content window
+func (s *encryptedStorageService) DownloadRange(object Object, offset, length int64) (io.Reader, error) {
+	return DownloadRange(s, object, offset, length)
+}
+
+//Upload encrypts and uploads provided reader content for supplied URL.
+func (s *encryptedStorageService) Upload(URL string, reader io.Reader) error {
+	return s.UploadWithOptions(URL, reader, UploadOptions{})
+}
+
+//UploadWithOptions encrypts and uploads provided reader content for supplied URL, content is compressed before encryption, other options are passed to the delegate
+func (s *encryptedStorageService) UploadWithOptions(URL string, reader io.Reader, options UploadOptions) error {
+	URL, reader = CompressedUpload(URL, reader, options)
+	options.Compress = false
+	aead, err := s.newCipher()
+	if err != nil {
+		return err
+	}
+	content, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+	var nonce = make([]byte, aead.NonceSize())
+	if _, err = io.ReadFull(rand.Reader, nonce); err != nil {
+		return err
+	}
+	return s.Service.UploadWithOptions(URL, bytes.NewReader(aead.Seal(nonce, nonce, content, nil)), options)
+}
+
+//NewEncryptedService creates a new storage service that encrypts content on upload and decrypts it on download with AES-GCM,
+//key has to be 16, 24 or 32 bytes long. Random nonce is stored as a prefix of the stored content.
+func NewEncryptedService(delegate Service, key []byte) Service {
+	return &encryptedStorageService{
+		Service: delegate,
+		key:     key,
+	}
+}
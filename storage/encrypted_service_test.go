@@ -0,0 +1,76 @@
+package storage_test
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/toolbox/storage"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+	"testing"
+)
+
+func TestNewEncryptedService(t *testing.T) {
+	delegate := storage.NewFileStorage()
+	service := storage.NewEncryptedService(delegate, []byte("0123456789abcdef0123456789abcdef"))
+	var URL = "file://" + path.Join(os.TempDir(), "encrypted_service_test", "secret.txt")
+
+	err := service.Upload(URL, strings.NewReader("top secret"))
+	assert.Nil(t, err)
+
+	exists, err := service.Exists(URL)
+	assert.Nil(t, err)
+	assert.True(t, exists)
+
+	object, err := service.StorageObject(URL)
+	if !assert.Nil(t, err) {
+		return
+	}
+	{
+		reader, err := delegate.Download(object)
+		assert.Nil(t, err)
+		stored, err := ioutil.ReadAll(reader)
+		assert.Nil(t, err)
+		assert.False(t, bytes.Contains(stored, []byte("top secret")))
+		assert.Equal(t, 12+len("top secret")+16, len(stored))
+
+		stored[len(stored)-1] ^= 0xFF
+		err = delegate.Upload(URL, bytes.NewReader(stored))
+		assert.Nil(t, err)
+		object, _ := service.StorageObject(URL)
+		_, err = service.Download(object)
+		assert.NotNil(t, err)
+	}
+	{
+		err := service.Upload(URL, strings.NewReader("top secret"))
+		assert.Nil(t, err)
+		object, _ := service.StorageObject(URL)
+		reader, err := service.Download(object)
+		if assert.Nil(t, err) {
+			content, err := ioutil.ReadAll(reader)
+			assert.Nil(t, err)
+			assert.Equal(t, "top secret", string(content))
+		}
+	}
+	{
+		reader, err := service.DownloadRange(object, 4, 3)
+		if assert.Nil(t, err) {
+			content, err := ioutil.ReadAll(reader)
+			assert.Nil(t, err)
+			assert.Equal(t, "sec", string(content))
+		}
+	}
+	{
+		withOtherKey := storage.NewEncryptedService(delegate, []byte("fedcba9876543210"))
+		_, err := withOtherKey.Download(object)
+		assert.NotNil(t, err)
+	}
+	{
+		invalidKey := storage.NewEncryptedService(delegate, []byte("abc"))
+		err := invalidKey.Upload(URL, strings.NewReader("abc"))
+		assert.NotNil(t, err)
+	}
+	err = service.Delete(object)
+	assert.Nil(t, err)
+}
@@ -1,22 +1,76 @@
 package storage
 
 import (
-	"bytes"
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
 	"fmt"
 	"github.com/pkg/errors"
 	"github.com/viant/toolbox"
+	"hash"
 	"io"
 	"io/ioutil"
 	"net/url"
 	"os"
 	"path"
+	"path/filepath"
 	"strings"
 )
 
 var fileMode os.FileMode = 0644
 
+//DefaultDirMode represents default permission of directories created by file storage service
+var DefaultDirMode os.FileMode = 0755
+
+//FileStorageOptions represents file storage service options
+type FileStorageOptions struct {
+	//Streaming writes uploads directly into the target file instead of temporary file renamed into place
+	Streaming bool
+	//FollowSymlinks resolves symlinks while listing, symlinked folders are reported as folders and traversed by ListRecursive
+	FollowSymlinks bool
+	//StrictPath disables creating missing parent directories on upload, upload into non existing directory fails
+	StrictPath bool
+	//DirMode represents permission of created directories, DefaultDirMode is used if not set
+	DirMode os.FileMode
+}
+
 //Service represents abstract way to accessing local or remote storage
-type fileStorageService struct{}
+//uploads are written to a temporary file in the target directory that is renamed into place on success, unless streaming is set,
+//missing parent directories are created unless strict path is set
+type fileStorageService struct {
+	streaming      bool
+	followSymlinks bool
+	strictPath     bool
+	dirMode        os.FileMode
+}
+
+//directoryMode returns permission of created directories
+func (s *fileStorageService) directoryMode() os.FileMode {
+	if s.dirMode == 0 {
+		return DefaultDirMode
+	}
+	return s.dirMode
+}
+
+//createParentDir creates missing parent directories of supplied file path unless strict path is set
+func (s *fileStorageService) createParentDir(filePath string) error {
+	if s.strictPath {
+		return nil
+	}
+	parentDir, _ := path.Split(filePath)
+	return os.MkdirAll(parentDir, s.directoryMode())
+}
+
+//resolveSymlink returns info of symlink target if symlinks are followed, broken symlinks are reported as is
+func (s *fileStorageService) resolveSymlink(filePath string, info os.FileInfo) os.FileInfo {
+	if !s.followSymlinks || info.Mode()&os.ModeSymlink == 0 {
+		return info
+	}
+	if targetInfo, err := os.Stat(filePath); err == nil {
+		return targetInfo
+	}
+	return info
+}
 
 func openFileFromUrl(URL string) (*os.File, error) {
 	parsedUrl, err := url.Parse(URL)
@@ -57,6 +111,7 @@ func (s *fileStorageService) List(URL string) ([]Object, error) {
 		var fileName = fileInfo.Name()
 		if parsedURL != nil {
 			fileName = strings.Replace(fileName, parsedURL.Path, "", 1)
+			fileInfo = s.resolveSymlink(path.Join(parsedURL.Path, fileInfo.Name()), fileInfo)
 		}
 		fileURL := toolbox.URLPathJoin(URL, fileName)
 		result = append(result, newFileObject(fileURL, fileInfo))
@@ -64,6 +119,88 @@ func (s *fileStorageService) List(URL string) ([]Object, error) {
 	return result, nil
 }
 
+//ListMatch returns objects for supplied url whose base name matches shell pattern
+func (s *fileStorageService) ListMatch(URL, pattern string) ([]Object, error) {
+	return ListMatch(s, URL, pattern)
+}
+
+//ListRecursive returns all files and folders under supplied url
+func (s *fileStorageService) ListRecursive(URL string) ([]Object, error) {
+	var result = make([]Object, 0)
+	err := s.Walk(URL, func(object Object) error {
+		result = append(result, object)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sortObjects(result)
+	return result, nil
+}
+
+//Walk calls visitor with each file and folder under supplied url in lexical order
+func (s *fileStorageService) Walk(URL string, visitor WalkVisitor) error {
+	rootPath, err := toolbox.FileFromURL(URL)
+	if err != nil {
+		return err
+	}
+	rootPath = filepath.Clean(rootPath)
+	if s.followSymlinks {
+		err = s.walkFollowingSymlinks(rootPath, make(map[string]bool), visitor)
+	} else {
+		err = filepath.Walk(rootPath, func(filePath string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if filePath == rootPath && info.IsDir() {
+				return nil
+			}
+			return visitor(newFileObject(toolbox.FileSchema+filePath, info))
+		})
+	}
+	if err != nil && err != StopWalkError {
+		return err
+	}
+	return nil
+}
+
+//walkFollowingSymlinks visits all objects under filePath, symlinked folders resolving to a folder being already traversed are reported but not traversed again
+func (s *fileStorageService) walkFollowingSymlinks(filePath string, ancestors map[string]bool, visitor WalkVisitor) error {
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return visitor(newFileObject(toolbox.FileSchema+filePath, info))
+	}
+	realPath, err := filepath.EvalSymlinks(filePath)
+	if err != nil {
+		return err
+	}
+	if ancestors[realPath] {
+		return nil
+	}
+	ancestors[realPath] = true
+	defer delete(ancestors, realPath)
+	files, err := ioutil.ReadDir(filePath)
+	if err != nil {
+		return err
+	}
+	for _, fileInfo := range files {
+		var childPath = path.Join(filePath, fileInfo.Name())
+		fileInfo = s.resolveSymlink(childPath, fileInfo)
+		if err = visitor(newFileObject(toolbox.FileSchema+childPath, fileInfo)); err != nil {
+			return err
+		}
+		if fileInfo.IsDir() {
+			if err = s.walkFollowingSymlinks(childPath, ancestors, visitor); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 //Exists returns true if resource exists
 func (s *fileStorageService) Exists(URL string) (bool, error) {
 	parsedUrl, err := url.Parse(URL)
@@ -76,6 +213,43 @@ func (s *fileStorageService) Exists(URL string) (bool, error) {
 	return toolbox.FileExists(parsedUrl.Path), nil
 }
 
+//ExistsPrefix returns true if directory of supplied URL has at least one entry starting with URL base name, URL with trailing slash checks directory content
+func (s *fileStorageService) ExistsPrefix(URL string) (bool, error) {
+	parsedUrl, err := url.Parse(URL)
+	if err != nil {
+		return false, err
+	}
+	if parsedUrl.Scheme != "file" {
+		return false, fmt.Errorf("Invalid schema, expected file but had: %v", parsedUrl.Scheme)
+	}
+	var directory, prefix = parsedUrl.Path, ""
+	if !strings.HasSuffix(parsedUrl.Path, "/") {
+		directory, prefix = filepath.Split(parsedUrl.Path)
+	}
+	file, err := os.Open(directory)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	defer file.Close()
+	for {
+		names, err := file.Readdirnames(256)
+		for _, name := range names {
+			if strings.HasPrefix(name, prefix) {
+				return true, nil
+			}
+		}
+		if err == io.EOF {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+	}
+}
+
 func (s *fileStorageService) Close() error {
 	return nil
 }
@@ -94,22 +268,92 @@ func (s *fileStorageService) StorageObject(URL string) (Object, error) {
 	return newFileObject(URL, fileInfo), nil
 }
 
-//Download returns reader for downloaded storage object
+//Download returns reader streaming storage object file content, reader implements io.Closer and should be closed
 func (s *fileStorageService) Download(object Object) (io.Reader, error) {
 	reader, _, err := toolbox.OpenReaderFromURL(object.URL())
 	if err != nil {
 		return nil, err
 	}
-	defer reader.Close()
-	data, err := ioutil.ReadAll(reader)
+	return reader, nil
+}
+
+//DownloadWithContext returns reader streaming storage object file content, reading is aborted once context is done,
+//reader implements io.Closer and should be closed
+func (s *fileStorageService) DownloadWithContext(ctx context.Context, object Object) (io.Reader, error) {
+	return DownloadWithContext(ctx, s, object)
+}
+
+func (s *fileStorageService) checksum(object Object, hasher hash.Hash) (string, error) {
+	file, err := openFileFromUrl(object.URL())
 	if err != nil {
+		return "", err
+	}
+	return checksum(file, hasher)
+}
+
+//ChecksumMD5 returns MD5 hex digest of file content
+func (s *fileStorageService) ChecksumMD5(object Object) (string, error) {
+	return s.checksum(object, md5.New())
+}
+
+//ChecksumSHA256 returns SHA-256 hex digest of file content
+func (s *fileStorageService) ChecksumSHA256(object Object) (string, error) {
+	return s.checksum(object, sha256.New())
+}
+
+//fileSectionReader represents file content window reader that closes the file
+type fileSectionReader struct {
+	*io.SectionReader
+	io.Closer
+}
+
+//DownloadRange returns reader for length bytes of storage object starting at offset, non positive length reads till the end of the file,
+//content is read from the open file on demand, thus returned reader implements io.Closer and should be closed
+func (s *fileStorageService) DownloadRange(object Object, offset, length int64) (io.Reader, error) {
+	file, err := openFileFromUrl(object.URL())
+	if err != nil {
+		return nil, err
+	}
+	stat, err := file.Stat()
+	if err == nil {
+		err = checkDownloadRange(object, stat.Size(), offset)
+	}
+	if err != nil {
+		file.Close()
 		return nil, err
 	}
-	return bytes.NewReader(data), err
+	var size = stat.Size() - offset
+	if length > 0 && length < size {
+		size = length
+	}
+	return &fileSectionReader{SectionReader: io.NewSectionReader(file, offset, size), Closer: file}, nil
 }
 
 //Upload uploads provided reader content for supplied url.
 func (s *fileStorageService) Upload(URL string, reader io.Reader) error {
+	return s.UploadWithContext(context.Background(), URL, reader)
+}
+
+//CreateFolder creates directory with all missing parent directories for supplied url
+func (s *fileStorageService) CreateFolder(URL string) error {
+	parsedUrl, err := url.Parse(URL)
+	if err != nil {
+		return err
+	}
+	if parsedUrl.Scheme != "file" {
+		return fmt.Errorf("Invalid schema, expected file but had: %v", parsedUrl.Scheme)
+	}
+	return os.MkdirAll(parsedUrl.Path, s.directoryMode())
+}
+
+//UploadWithOptions uploads provided reader content for supplied url, only compress option is used
+func (s *fileStorageService) UploadWithOptions(URL string, reader io.Reader, options UploadOptions) error {
+	URL, reader = CompressedUpload(URL, reader, options)
+	return s.Upload(URL, reader)
+}
+
+//UploadWithContext uploads provided reader content for supplied url, partially written file is removed on error or once context is done
+func (s *fileStorageService) UploadWithContext(ctx context.Context, URL string, reader io.Reader) error {
 	parsedUrl, err := url.Parse(URL)
 	if err != nil {
 		return err
@@ -118,17 +362,65 @@ func (s *fileStorageService) Upload(URL string, reader io.Reader) error {
 		return fmt.Errorf("Invalid schema, expected file but had: %v", parsedUrl.Scheme)
 	}
 
+	if err = s.createParentDir(parsedUrl.Path); err != nil {
+		return err
+	}
 	parentDir, _ := path.Split(parsedUrl.Path)
+	var file *os.File
+	if s.streaming {
+		file, err = os.OpenFile(parsedUrl.Path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, fileMode)
+	} else {
+		_, name := path.Split(parsedUrl.Path)
+		file, err = ioutil.TempFile(parentDir, "."+name+".tmp")
+	}
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(file, NewContextReader(ctx, reader))
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		err = ctxErr
+	}
+	if err == nil && !s.streaming {
+		if err = os.Chmod(file.Name(), fileMode); err == nil {
+			err = os.Rename(file.Name(), parsedUrl.Path)
+		}
+	}
+	if err != nil {
+		os.Remove(file.Name())
+	}
+	return err
+}
+
+//DeleteAll removes passed in storage objects
+func (s *fileStorageService) DeleteAll(objects []Object) error {
+	return DeleteAll(s, objects)
+}
+
+//Copy copies object from source URL to destination URL
+func (s *fileStorageService) Copy(sourceURL, destinationURL string) error {
+	return CopyObject(s, sourceURL, s, destinationURL)
+}
 
-	err = toolbox.CreateDirIfNotExist(parentDir)
+//Move moves object from source URL to destination URL, it renames the file when possible
+func (s *fileStorageService) Move(sourceURL, destinationURL string) error {
+	sourcePath, err := toolbox.FileFromURL(sourceURL)
 	if err != nil {
 		return err
 	}
-	data, err := ioutil.ReadAll(reader)
+	destinationPath, err := toolbox.FileFromURL(destinationURL)
 	if err != nil {
 		return err
 	}
-	return ioutil.WriteFile(parsedUrl.Path, data, fileMode)
+	if err = s.createParentDir(destinationPath); err != nil {
+		return err
+	}
+	if err = os.Rename(sourcePath, destinationPath); err == nil {
+		return nil
+	}
+	return MoveObject(s, sourceURL, s, destinationURL)
 }
 
 func (s *fileStorageService) Register(schema string, service Service) error {
@@ -148,10 +440,26 @@ type fileStorageObject struct {
 	*AbstractObject
 }
 
+//NewFileStorage creates a new file storage service with atomic (temp file then rename) uploads
 func NewFileStorage() Service {
 	return &fileStorageService{}
 }
 
+//NewStreamingFileStorage creates a new file storage service writing uploads directly into the target file
+func NewStreamingFileStorage() Service {
+	return &fileStorageService{streaming: true}
+}
+
+//NewFileStorageWithOptions creates a new file storage service with supplied options
+func NewFileStorageWithOptions(options FileStorageOptions) Service {
+	return &fileStorageService{
+		streaming:      options.Streaming,
+		followSymlinks: options.FollowSymlinks,
+		strictPath:     options.StrictPath,
+		dirMode:        options.DirMode,
+	}
+}
+
 func (o *fileStorageObject) Unwrap(target interface{}) error {
 	if fileInfo, casted := target.(*os.FileInfo); casted {
 		source, ok := o.Source.(os.FileInfo)
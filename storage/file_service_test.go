@@ -0,0 +1,381 @@
+package storage_test
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/toolbox"
+	"github.com/viant/toolbox/storage"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFileStorageService_ListRecursive(t *testing.T) {
+	service := storage.NewFileStorage()
+	var baseDir = path.Join(os.TempDir(), "file_list_recursive_test")
+	defer os.RemoveAll(baseDir)
+	for _, name := range []string{"file1.txt", "sub/file2.txt", "sub/nested/file3.txt"} {
+		err := service.Upload(toolbox.FileSchema+path.Join(baseDir, name), strings.NewReader("abc"))
+		assert.Nil(t, err)
+	}
+
+	objects, err := service.ListRecursive(toolbox.FileSchema + baseDir)
+	assert.Nil(t, err)
+	var actual = make(map[string]bool)
+	for _, object := range objects {
+		actual[object.URL()] = object.IsFolder()
+	}
+	assert.EqualValues(t, map[string]bool{
+		toolbox.FileSchema + path.Join(baseDir, "file1.txt"):            false,
+		toolbox.FileSchema + path.Join(baseDir, "sub"):                  true,
+		toolbox.FileSchema + path.Join(baseDir, "sub/file2.txt"):        false,
+		toolbox.FileSchema + path.Join(baseDir, "sub/nested"):           true,
+		toolbox.FileSchema + path.Join(baseDir, "sub/nested/file3.txt"): false,
+	}, actual)
+
+	{ //generic implementation based on List
+		genericObjects, err := storage.ListRecursive(service, toolbox.FileSchema+baseDir)
+		assert.Nil(t, err)
+		assert.Equal(t, len(objects), len(genericObjects))
+		for i := range objects {
+			assert.Equal(t, objects[i].URL(), genericObjects[i].URL())
+		}
+	}
+
+	_, err = service.ListRecursive(toolbox.FileSchema + path.Join(baseDir, "missing"))
+	assert.NotNil(t, err)
+}
+
+func TestFileStorageService_Walk(t *testing.T) {
+	service := storage.NewFileStorage()
+	var baseDir = path.Join(os.TempDir(), "file_walk_test")
+	defer os.RemoveAll(baseDir)
+	for _, name := range []string{"file1.txt", "sub/file2.txt", "sub/nested/file3.txt"} {
+		err := service.Upload(toolbox.FileSchema+path.Join(baseDir, name), strings.NewReader("abc"))
+		assert.Nil(t, err)
+	}
+	var visited = make([]string, 0)
+	err := service.Walk(toolbox.FileSchema+baseDir, func(object storage.Object) error {
+		visited = append(visited, object.URL())
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{
+		toolbox.FileSchema + path.Join(baseDir, "file1.txt"),
+		toolbox.FileSchema + path.Join(baseDir, "sub"),
+		toolbox.FileSchema + path.Join(baseDir, "sub/file2.txt"),
+		toolbox.FileSchema + path.Join(baseDir, "sub/nested"),
+		toolbox.FileSchema + path.Join(baseDir, "sub/nested/file3.txt"),
+	}, visited)
+
+	var count = 0
+	err = service.Walk(toolbox.FileSchema+baseDir, func(object storage.Object) error {
+		count++
+		if object.IsFolder() {
+			return storage.StopWalkError
+		}
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, count)
+
+	err = service.Walk(toolbox.FileSchema+baseDir, func(object storage.Object) error {
+		return errors.New("test error")
+	})
+	assert.NotNil(t, err)
+
+	err = service.Walk(toolbox.FileSchema+path.Join(baseDir, "missing"), func(object storage.Object) error {
+		return nil
+	})
+	assert.NotNil(t, err)
+}
+
+func TestFileStorageService_UploadParentDirectories(t *testing.T) {
+	var baseDir = path.Join(os.TempDir(), "file_upload_parent_test")
+	os.RemoveAll(baseDir)
+	defer os.RemoveAll(baseDir)
+	{ //missing parent directories are created
+		service := storage.NewFileStorage()
+		var filename = path.Join(baseDir, "default/a/b/c.txt")
+		assert.Nil(t, service.Upload(toolbox.FileSchema+filename, strings.NewReader("abc")))
+		content, err := ioutil.ReadFile(filename)
+		assert.Nil(t, err)
+		assert.Equal(t, "abc", string(content))
+	}
+	{ //custom directory permission
+		service := storage.NewFileStorageWithOptions(storage.FileStorageOptions{DirMode: 0700})
+		assert.Nil(t, service.Upload(toolbox.FileSchema+path.Join(baseDir, "custom/a/c.txt"), strings.NewReader("abc")))
+		info, err := os.Stat(path.Join(baseDir, "custom/a"))
+		if assert.Nil(t, err) {
+			assert.EqualValues(t, os.FileMode(0700), info.Mode().Perm())
+		}
+	}
+	{ //strict path does not create directories
+		service := storage.NewFileStorageWithOptions(storage.FileStorageOptions{StrictPath: true})
+		var URL = toolbox.FileSchema + path.Join(baseDir, "strict/a/c.txt")
+		assert.NotNil(t, service.Upload(URL, strings.NewReader("abc")))
+		assert.False(t, toolbox.FileExists(path.Join(baseDir, "strict")))
+		assert.Nil(t, service.Upload(toolbox.FileSchema+path.Join(baseDir, "default/a/b/d.txt"), strings.NewReader("abc")))
+	}
+}
+
+func TestFileStorageService_MoveParentDirectories(t *testing.T) {
+	var baseDir = path.Join(os.TempDir(), "file_move_parent_test")
+	os.RemoveAll(baseDir)
+	defer os.RemoveAll(baseDir)
+	assert.Nil(t, os.MkdirAll(baseDir, 0755))
+	var sourceURL = toolbox.FileSchema + path.Join(baseDir, "source.txt")
+	{ //strict path does not create directories
+		service := storage.NewFileStorageWithOptions(storage.FileStorageOptions{StrictPath: true})
+		assert.Nil(t, service.Upload(sourceURL, strings.NewReader("abc")))
+		assert.NotNil(t, service.Move(sourceURL, toolbox.FileSchema+path.Join(baseDir, "strict/a/c.txt")))
+		assert.False(t, toolbox.FileExists(path.Join(baseDir, "strict")))
+	}
+	{ //custom directory permission
+		service := storage.NewFileStorageWithOptions(storage.FileStorageOptions{DirMode: 0700})
+		assert.Nil(t, service.Move(sourceURL, toolbox.FileSchema+path.Join(baseDir, "custom/a/c.txt")))
+		info, err := os.Stat(path.Join(baseDir, "custom/a"))
+		if assert.Nil(t, err) {
+			assert.EqualValues(t, os.FileMode(0700), info.Mode().Perm())
+		}
+		content, err := ioutil.ReadFile(path.Join(baseDir, "custom/a/c.txt"))
+		assert.Nil(t, err)
+		assert.Equal(t, "abc", string(content))
+	}
+}
+
+func TestFileStorageService_CreateFolder(t *testing.T) {
+	service := storage.NewFileStorage()
+	var baseDir = path.Join(os.TempDir(), "file_create_folder_test")
+	os.RemoveAll(baseDir)
+	defer os.RemoveAll(baseDir)
+	err := service.CreateFolder(toolbox.FileSchema + path.Join(baseDir, "sub/nested"))
+	assert.Nil(t, err)
+	objects, err := service.ListRecursive(toolbox.FileSchema + baseDir)
+	assert.Nil(t, err)
+	var actual = make(map[string]bool)
+	for _, object := range objects {
+		actual[object.URL()] = object.IsFolder()
+	}
+	assert.EqualValues(t, map[string]bool{
+		toolbox.FileSchema + path.Join(baseDir, "sub"):        true,
+		toolbox.FileSchema + path.Join(baseDir, "sub/nested"): true,
+	}, actual)
+	assert.Nil(t, service.CreateFolder(toolbox.FileSchema+path.Join(baseDir, "sub")))
+
+	assert.Nil(t, service.Upload(toolbox.FileSchema+path.Join(baseDir, "file.txt"), strings.NewReader("abc")))
+	err = service.CreateFolder(toolbox.FileSchema + path.Join(baseDir, "file.txt"))
+	assert.NotNil(t, err)
+}
+
+func TestFileStorageService_DownloadRange(t *testing.T) {
+	service := storage.NewFileStorage()
+	var baseDir = path.Join(os.TempDir(), "file_download_range_test")
+	defer os.RemoveAll(baseDir)
+	var URL = toolbox.FileSchema + path.Join(baseDir, "data.txt")
+	err := service.Upload(URL, strings.NewReader("0123456789"))
+	assert.Nil(t, err)
+	object, err := service.StorageObject(URL)
+	if !assert.Nil(t, err) {
+		return
+	}
+	var useCases = []struct {
+		offset, length int64
+		expected       string
+	}{
+		{0, 3, "012"},
+		{4, 2, "45"},
+		{7, 0, "789"},
+		{8, 10, "89"},
+	}
+	for _, useCase := range useCases {
+		reader, err := service.DownloadRange(object, useCase.offset, useCase.length)
+		if assert.Nil(t, err) {
+			content, err := ioutil.ReadAll(reader)
+			assert.Nil(t, err)
+			assert.Equal(t, useCase.expected, string(content))
+			if closer, ok := reader.(io.Closer); assert.True(t, ok) {
+				assert.Nil(t, closer.Close())
+			}
+		}
+		{ //generic implementation based on Download
+			reader, err := storage.DownloadRange(service, object, useCase.offset, useCase.length)
+			if assert.Nil(t, err) {
+				content, err := ioutil.ReadAll(reader)
+				assert.Nil(t, err)
+				assert.Equal(t, useCase.expected, string(content))
+			}
+		}
+	}
+	_, err = service.DownloadRange(object, 11, 0)
+	assert.NotNil(t, err)
+	_, err = storage.DownloadRange(service, object, 11, 0)
+	assert.NotNil(t, err)
+}
+
+//failingReader returns some content and then fails
+type failingReader struct {
+	reads int
+}
+
+func (r *failingReader) Read(p []byte) (int, error) {
+	r.reads++
+	if r.reads > 1 {
+		return 0, errors.New("connection reset")
+	}
+	return copy(p, []byte("partial")), nil
+}
+
+func TestFileStorageService_AtomicUpload(t *testing.T) {
+	service := storage.NewFileStorage()
+	var baseDir = path.Join(os.TempDir(), "file_atomic_upload_test")
+	defer os.RemoveAll(baseDir)
+	var filePath = path.Join(baseDir, "data.txt")
+
+	err := service.Upload(toolbox.FileSchema+filePath, &failingReader{})
+	assert.NotNil(t, err)
+	assert.False(t, toolbox.FileExists(filePath))
+
+	err = service.Upload(toolbox.FileSchema+filePath, strings.NewReader("original"))
+	assert.Nil(t, err)
+	err = service.Upload(toolbox.FileSchema+filePath, &failingReader{})
+	assert.NotNil(t, err)
+	content, err := ioutil.ReadFile(filePath)
+	assert.Nil(t, err)
+	assert.Equal(t, "original", string(content))
+
+	files, err := ioutil.ReadDir(baseDir)
+	if assert.Nil(t, err) && assert.Equal(t, 1, len(files)) {
+		assert.Equal(t, "data.txt", files[0].Name())
+		assert.Equal(t, os.FileMode(0644), files[0].Mode().Perm())
+	}
+
+	{ //streaming uploads write directly into target
+		streaming := storage.NewStreamingFileStorage()
+		err = streaming.Upload(toolbox.FileSchema+filePath, strings.NewReader("streamed"))
+		assert.Nil(t, err)
+		content, err := ioutil.ReadFile(filePath)
+		assert.Nil(t, err)
+		assert.Equal(t, "streamed", string(content))
+	}
+}
+
+func TestFileStorageService_StorageObjectMetadata(t *testing.T) {
+	service := storage.NewFileStorage()
+	var baseDir = path.Join(os.TempDir(), "file_metadata_test")
+	defer os.RemoveAll(baseDir)
+	var fileURL = toolbox.FileSchema + path.Join(baseDir, "data.txt")
+
+	var before = time.Now().Add(-2 * time.Second)
+	err := service.Upload(fileURL, strings.NewReader("metadata"))
+	assert.Nil(t, err)
+	object, err := service.StorageObject(fileURL)
+	if assert.Nil(t, err) {
+		assert.Equal(t, int64(8), object.Size())
+		assert.True(t, object.ModTime().After(before))
+		assert.False(t, object.IsFolder())
+	}
+	folder, err := service.StorageObject(toolbox.FileSchema + baseDir)
+	if assert.Nil(t, err) {
+		assert.True(t, folder.IsFolder())
+	}
+}
+
+func TestFileStorageService_ExistsPrefix(t *testing.T) {
+	service := storage.NewFileStorage()
+	var baseDir = path.Join(os.TempDir(), "file_exists_prefix_test")
+	os.RemoveAll(baseDir)
+	defer os.RemoveAll(baseDir)
+	err := os.MkdirAll(path.Join(baseDir, "empty"), 0755)
+	assert.Nil(t, err)
+	err = service.Upload(toolbox.FileSchema+path.Join(baseDir, "data/report_2018.csv"), strings.NewReader("a,b"))
+	assert.Nil(t, err)
+
+	var useCases = []struct {
+		URL      string
+		expected bool
+	}{
+		{toolbox.FileSchema + path.Join(baseDir, "data/report"), true},
+		{toolbox.FileSchema + path.Join(baseDir, "data/report_2018.csv"), true},
+		{toolbox.FileSchema + path.Join(baseDir, "data/summary"), false},
+		{toolbox.FileSchema + path.Join(baseDir, "data") + "/", true},
+		{toolbox.FileSchema + path.Join(baseDir, "empty") + "/", false},
+		{toolbox.FileSchema + path.Join(baseDir, "missing") + "/", false},
+		{toolbox.FileSchema + path.Join(baseDir, "missing/report"), false},
+	}
+	for _, useCase := range useCases {
+		exists, err := service.ExistsPrefix(useCase.URL)
+		assert.Nil(t, err, useCase.URL)
+		assert.Equal(t, useCase.expected, exists, useCase.URL)
+	}
+}
+
+func TestFileStorageService_FollowSymlinks(t *testing.T) {
+	var baseDir = path.Join(os.TempDir(), "file_symlink_test")
+	os.RemoveAll(baseDir)
+	defer os.RemoveAll(baseDir)
+	var treeDir = path.Join(baseDir, "tree")
+	for _, name := range []string{"shared/lib.txt", "tree/app.txt"} {
+		err := storage.NewFileStorage().Upload(toolbox.FileSchema+path.Join(baseDir, name), strings.NewReader("abc"))
+		assert.Nil(t, err)
+	}
+	if !assert.Nil(t, os.Symlink(path.Join(baseDir, "shared"), path.Join(treeDir, "shared"))) {
+		return
+	}
+	//self referential cycle
+	if !assert.Nil(t, os.Symlink(treeDir, path.Join(treeDir, "loop"))) {
+		return
+	}
+	var listURLs = func(objects []storage.Object) map[string]storage.Object {
+		var result = make(map[string]storage.Object)
+		for _, object := range objects {
+			result[strings.Replace(object.URL(), toolbox.FileSchema+treeDir, "", 1)] = object
+		}
+		return result
+	}
+
+	{ //default behaviour reports symlinks as is
+		service := storage.NewFileStorage()
+		objects, err := service.List(toolbox.FileSchema + treeDir)
+		assert.Nil(t, err)
+		var byURL = listURLs(objects)
+		if assert.NotNil(t, byURL["/shared"]) {
+			assert.False(t, byURL["/shared"].IsFolder())
+		}
+		objects, err = service.ListRecursive(toolbox.FileSchema + treeDir)
+		assert.Nil(t, err)
+		byURL = listURLs(objects)
+		assert.Equal(t, 3, len(objects))
+		assert.Nil(t, byURL["/shared/lib.txt"])
+	}
+
+	{ //following symlinks resolves symlinked folders and stops on cycles
+		service := storage.NewFileStorageWithOptions(storage.FileStorageOptions{FollowSymlinks: true})
+		objects, err := service.List(toolbox.FileSchema + treeDir)
+		assert.Nil(t, err)
+		var byURL = listURLs(objects)
+		if assert.NotNil(t, byURL["/shared"]) {
+			assert.True(t, byURL["/shared"].IsFolder())
+		}
+		if assert.NotNil(t, byURL["/loop"]) {
+			assert.True(t, byURL["/loop"].IsFolder())
+		}
+
+		objects, err = service.ListRecursive(toolbox.FileSchema + treeDir)
+		assert.Nil(t, err)
+		byURL = listURLs(objects)
+		var actual = make([]string, 0)
+		for _, object := range objects {
+			actual = append(actual, strings.Replace(object.URL(), toolbox.FileSchema+treeDir, "", 1))
+		}
+		assert.Equal(t, []string{"/app.txt", "/loop", "/shared", "/shared/lib.txt"}, actual)
+		if assert.NotNil(t, byURL["/shared/lib.txt"]) {
+			assert.True(t, byURL["/shared/lib.txt"].IsContent())
+			assert.Equal(t, int64(3), byURL["/shared/lib.txt"].Size())
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package gs
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/stretchr/testify/assert"
+	tstorage "github.com/viant/toolbox/storage"
+	"google.golang.org/api/iterator"
+)
+
+type fakeIterator struct {
+	objects []*storage.ObjectAttrs
+	err     error
+}
+
+func (i *fakeIterator) Next() (*storage.ObjectAttrs, error) {
+	if len(i.objects) == 0 {
+		if i.err != nil {
+			return nil, i.err
+		}
+		return nil, iterator.Done
+	}
+	var result = i.objects[0]
+	i.objects = i.objects[1:]
+	return result, nil
+}
+
+func TestListObjects(t *testing.T) {
+	{
+		objects, err := listObjects("bucket", &fakeIterator{
+			objects: []*storage.ObjectAttrs{
+				{Prefix: "data/folder/"},
+				{Bucket: "bucket", Name: "data/file.json", Size: 10, Updated: time.Now()},
+			},
+		})
+		assert.Nil(t, err)
+		if assert.Equal(t, 2, len(objects)) {
+			assert.Equal(t, "gs://bucket/data/folder/", objects[0].URL())
+			assert.True(t, objects[0].IsFolder())
+			assert.Equal(t, "gs://bucket/data/file.json", objects[1].URL())
+			assert.True(t, objects[1].IsContent())
+			assert.Equal(t, int64(10), objects[1].FileInfo().Size())
+		}
+	}
+	{
+		_, err := listObjects("bucket", &fakeIterator{err: errors.New("access denied")})
+		assert.NotNil(t, err)
+	}
+}
+
+func TestContentType(t *testing.T) {
+	assert.Equal(t, "application/json", contentType("data/file.json"))
+	assert.Equal(t, "", contentType("data/file"))
+}
+
+func TestServiceProvider(t *testing.T) {
+	for _, scheme := range []string{ProviderScheme, GSProviderScheme} {
+		assert.NotNil(t, tstorage.NewStorageProvider().Get(scheme))
+	}
+}
@@ -23,6 +23,14 @@ func (o *object) Unwrap(target interface{}) error {
 	return fmt.Errorf("unsuported target %T", target)
 }
 
+//ContentType returns content type stored with object attributes
+func (o *object) ContentType() string {
+	if attrs, ok := o.Source.(*storage.ObjectAttrs); ok && attrs != nil {
+		return attrs.ContentType
+	}
+	return ""
+}
+
 //newObject creates a new gc storage object
 func newStorageObject(url string, source interface{}, fileInfo os.FileInfo) tstorage.Object {
 	abstract := tstorage.NewAbstractStorageObject(url, source, fileInfo)
@@ -1,17 +1,46 @@
 package gs
 
 import (
+	"encoding/json"
+	"fmt"
+	"github.com/viant/toolbox"
 	"github.com/viant/toolbox/storage"
 	"google.golang.org/api/option"
 )
 
 const ProviderScheme = "gc"
 
+//GSProviderScheme represents gs:// url scheme
+const GSProviderScheme = "gs"
+
 func init() {
 	storage.NewStorageProvider().Registry[ProviderScheme] = serviceProvider
+	storage.NewStorageProvider().Registry[GSProviderScheme] = serviceProvider
+	storage.NewStorageProvider().ConfigRegistry[ProviderScheme] = serviceConfigProvider
+	storage.NewStorageProvider().ConfigRegistry[GSProviderScheme] = serviceConfigProvider
 }
 
 func serviceProvider(credentialFile string) (storage.Service, error) {
 	credentialOption := option.WithServiceAccountFile(credentialFile)
 	return NewService(credentialOption), nil
 }
+
+//serviceConfigProvider creates a service for service account JSON ([]byte or decoded map) or client option config, nil config uses default credentials
+func serviceConfigProvider(config interface{}) (storage.Service, error) {
+	switch value := config.(type) {
+	case nil:
+		return NewService(), nil
+	case option.ClientOption:
+		return NewService(value), nil
+	case []byte:
+		return NewService(option.WithCredentialsJSON(value)), nil
+	}
+	if !toolbox.IsMap(config) {
+		return nil, fmt.Errorf("unsupported gs config type: %T", config)
+	}
+	credentials, err := json.Marshal(toolbox.AsMap(config))
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode gs credentials: %v", err)
+	}
+	return NewService(option.WithCredentialsJSON(credentials)), nil
+}
@@ -9,11 +9,15 @@ import (
 	"fmt"
 	"github.com/viant/toolbox"
 	tstorage "github.com/viant/toolbox/storage"
+	"google.golang.org/api/iterator"
 	"google.golang.org/api/option"
 	"hash/crc32"
 	"io"
 	"io/ioutil"
+	"mime"
 	"net/url"
+	"path"
+	"strings"
 )
 
 type service struct {
@@ -21,11 +25,48 @@ type service struct {
 }
 
 func (s *service) NewClient() (*storage.Client, context.Context, error) {
-	ctx := context.Background()
+	return s.newClient(context.Background())
+}
+
+func (s *service) newClient(ctx context.Context) (*storage.Client, context.Context, error) {
 	client, err := storage.NewClient(ctx, s.options...)
 	return client, ctx, err
 }
 
+//objectIterator represents storage object attributes iterator
+type objectIterator interface {
+	Next() (*storage.ObjectAttrs, error)
+}
+
+//listObjects converts iterated objects into storage objects, prefixes (folders) are returned as folder objects
+func listObjects(bucket string, objects objectIterator) ([]tstorage.Object, error) {
+	var result = make([]tstorage.Object, 0)
+	for {
+		obj, err := objects.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		objectURL := "gs://" + bucket + "/" + obj.Prefix + obj.Name
+		var fileMode, _ = tstorage.NewFileMode("-rw-rw-rw-")
+		if obj.Prefix != "" {
+			fileMode, _ = tstorage.NewFileMode("drw-rw-rw-")
+		}
+		var _, name = toolbox.URLSplit(objectURL)
+		var fileInfo = tstorage.NewFileInfo(name, obj.Size, fileMode, obj.Updated, fileMode.IsDir())
+		var object = newStorageObject(objectURL, obj, fileInfo)
+		result = append(result, object)
+	}
+	return result, nil
+}
+
+//contentType returns content type for supplied object name extension or empty string if unknown
+func contentType(name string) string {
+	return mime.TypeByExtension(path.Ext(name))
+}
+
 //List returns a list of object for supplied url
 func (s *service) List(URL string) ([]tstorage.Object, error) {
 	parsedUrl, err := url.Parse(URL)
@@ -44,20 +85,7 @@ func (s *service) List(URL string) ([]tstorage.Object, error) {
 	if len(parsedUrl.Path) > 0 {
 		query.Prefix = parsedUrl.Path[1:]
 	}
-	responseIterator := client.Bucket(parsedUrl.Host).Objects(ctx, query)
-	var result = make([]tstorage.Object, 0)
-	for obj, err := responseIterator.Next(); err == nil; obj, err = responseIterator.Next() {
-		objectURL := "gs://" + parsedUrl.Host + "/" + obj.Prefix + obj.Name
-		var fileMode, _ = tstorage.NewFileMode("-rw-rw-rw-")
-		if obj.Prefix != "" {
-			fileMode, _ = tstorage.NewFileMode("drw-rw-rw-")
-		}
-		var _, name = toolbox.URLSplit(objectURL)
-		var fileInfo = tstorage.NewFileInfo(name, obj.Size, fileMode, obj.Updated, fileMode.IsDir())
-		var object = newStorageObject(objectURL, obj, fileInfo)
-		result = append(result, object)
-	}
-	return result, err
+	return listObjects(parsedUrl.Host, client.Bucket(parsedUrl.Host).Objects(ctx, query))
 }
 
 func (s *service) Exists(URL string) (bool, error) {
@@ -68,6 +96,20 @@ func (s *service) Exists(URL string) (bool, error) {
 	return len(objects) > 0, nil
 }
 
+//ExistsPrefix returns true if at least one object URL starts with supplied URL, it uses native prefix listing
+func (s *service) ExistsPrefix(URL string) (bool, error) {
+	objects, err := s.List(URL)
+	if err != nil {
+		return false, err
+	}
+	for _, object := range objects {
+		if strings.HasPrefix(strings.TrimSuffix(object.URL(), "/"), URL) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 func (s *service) StorageObject(URL string) (tstorage.Object, error) {
 	objects, err := s.List(URL)
 	if err != nil {
@@ -81,7 +123,12 @@ func (s *service) StorageObject(URL string) (tstorage.Object, error) {
 
 //Download returns reader for downloaded storage object
 func (s *service) Download(object tstorage.Object) (io.Reader, error) {
-	client, ctx, err := s.NewClient()
+	return s.DownloadWithContext(context.Background(), object)
+}
+
+//DownloadWithContext returns reader for downloaded storage object, download is cancelled once context is done
+func (s *service) DownloadWithContext(ctx context.Context, object tstorage.Object) (io.Reader, error) {
+	client, ctx, err := s.newClient(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -101,19 +148,42 @@ func (s *service) Download(object tstorage.Object) (io.Reader, error) {
 	}
 	defer reader.Close()
 	content, err := ioutil.ReadAll(reader)
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return nil, ctxErr
+	}
 	if err != nil {
 		return nil, err
 	}
 	return bytes.NewReader(content), err
 }
 
+//CreateFolder is no-op as google storage folders are virtual, they exist as long as there is an object under folder name prefix
+func (s *service) CreateFolder(URL string) error {
+	return nil
+}
+
 //Upload uploads provided reader content for supplied url.
 func (s *service) Upload(URL string, reader io.Reader) error {
+	return s.upload(context.Background(), URL, reader, tstorage.UploadOptions{})
+}
+
+//UploadWithContext uploads provided reader content for supplied url, upload is cancelled once context is done
+func (s *service) UploadWithContext(ctx context.Context, URL string, reader io.Reader) error {
+	return s.upload(ctx, URL, reader, tstorage.UploadOptions{})
+}
+
+//UploadWithOptions uploads provided reader content for supplied url with content type, cache control and metadata
+func (s *service) UploadWithOptions(URL string, reader io.Reader, options tstorage.UploadOptions) error {
+	URL, reader = tstorage.CompressedUpload(URL, reader, options)
+	return s.upload(context.Background(), URL, reader, options)
+}
+
+func (s *service) upload(ctx context.Context, URL string, reader io.Reader, options tstorage.UploadOptions) error {
 	parsedUrl, err := url.Parse(URL)
 	if err != nil {
 		return err
 	}
-	client, ctx, err := s.NewClient()
+	client, ctx, err := s.newClient(ctx)
 	if err != nil {
 		return err
 	}
@@ -126,11 +196,23 @@ func (s *service) Upload(URL string, reader io.Reader) error {
 		Object(name).
 		NewWriter(ctx)
 
+	writer.ContentType = contentType(name)
+	if options.ContentType != "" {
+		writer.ContentType = options.ContentType
+	}
+	writer.CacheControl = options.CacheControl
+	if len(options.Metadata) > 0 {
+		writer.Metadata = make(map[string]string)
+		for key, value := range options.Metadata {
+			writer.Metadata[key] = value
+		}
+	}
 	expiry := parsedUrl.Query().Get("expiry")
 	if expiry != "" {
-		writer.Metadata = map[string]string{
-			"Cache-Control": "private, max-age=" + expiry,
+		if writer.Metadata == nil {
+			writer.Metadata = make(map[string]string)
 		}
+		writer.Metadata["Cache-Control"] = "private, max-age=" + expiry
 	}
 	content, err := ioutil.ReadAll(reader)
 	if err != nil {
@@ -156,11 +238,59 @@ func (s *service) Upload(URL string, reader io.Reader) error {
 		return err
 	}
 	if err = writer.Close(); err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
 		return err
 	}
 	return err
 }
 
+//ChecksumMD5 returns MD5 hex digest of storage object content
+func (s *service) ChecksumMD5(object tstorage.Object) (string, error) {
+	return tstorage.ChecksumMD5(s, object)
+}
+
+//ChecksumSHA256 returns SHA-256 hex digest of storage object content
+func (s *service) ChecksumSHA256(object tstorage.Object) (string, error) {
+	return tstorage.ChecksumSHA256(s, object)
+}
+
+//DownloadRange returns reader for downloaded storage object content window
+func (s *service) DownloadRange(object tstorage.Object, offset, length int64) (io.Reader, error) {
+	return tstorage.DownloadRange(s, object, offset, length)
+}
+
+//ListMatch returns objects for supplied url whose base name matches shell pattern
+func (s *service) ListMatch(URL, pattern string) ([]tstorage.Object, error) {
+	return tstorage.ListMatch(s, URL, pattern)
+}
+
+//ListRecursive returns all objects under supplied url
+func (s *service) ListRecursive(URL string) ([]tstorage.Object, error) {
+	return tstorage.ListRecursive(s, URL)
+}
+
+//Walk calls visitor with each object under supplied url
+func (s *service) Walk(URL string, visitor tstorage.WalkVisitor) error {
+	return tstorage.Walk(s, URL, visitor)
+}
+
+//DeleteAll removes passed in storage objects
+func (s *service) DeleteAll(objects []tstorage.Object) error {
+	return tstorage.DeleteAll(s, objects)
+}
+
+//Copy copies object from source URL to destination URL
+func (s *service) Copy(sourceURL, destinationURL string) error {
+	return tstorage.CopyObject(s, sourceURL, s, destinationURL)
+}
+
+//Move moves object from source URL to destination URL
+func (s *service) Move(sourceURL, destinationURL string) error {
+	return tstorage.MoveObject(s, sourceURL, s, destinationURL)
+}
+
 func (s *service) Register(schema string, service tstorage.Service) error {
 	return errors.New("unsupported")
 }
@@ -2,6 +2,7 @@ package storage
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"github.com/pkg/errors"
 	"github.com/viant/toolbox"
@@ -152,63 +153,181 @@ func (s *httpStorageService) List(URL string) ([]Object, error) {
 	return result, err
 }
 
-//Exists returns true if resource exists
-func (s *httpStorageService) Exists(URL string) (bool, error) {
+func isSuccessStatus(statusCode int) bool {
+	return statusCode >= 200 && statusCode < 300
+}
+
+func (s *httpStorageService) head(URL string) (*http.Response, error) {
 	client, err := newHttpClient()
 	if err != nil {
-		return false, err
+		return nil, err
 	}
-	response, err := client.Get(URL)
+	response, err := client.Head(s.addCredentialToURLIfNeeded(URL))
+	if err != nil {
+		return nil, err
+	}
+	response.Body.Close()
+	return response, nil
+}
+
+//ExistsPrefix returns true if resource exists, http storage has no listing so URL is checked as is
+func (s *httpStorageService) ExistsPrefix(URL string) (bool, error) {
+	return s.Exists(URL)
+}
+
+//Exists returns true if resource exists, it uses HEAD request
+func (s *httpStorageService) Exists(URL string) (bool, error) {
+	response, err := s.head(URL)
 	if err != nil {
 		return false, err
 	}
-	return response.StatusCode == 200, nil
+	if response.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+	if !isSuccessStatus(response.StatusCode) {
+		return false, fmt.Errorf("invalid response code: %v, %v", response.Status, URL)
+	}
+	return true, nil
 }
 
-//Object returns a Object for supplied url
+//Object returns a Object for supplied url, size and modification time are taken from response headers
 func (s *httpStorageService) StorageObject(URL string) (Object, error) {
-	objects, err := s.List(URL)
+	response, err := s.head(URL)
 	if err != nil {
 		return nil, err
 	}
-	if len(objects) == 0 {
+	if response.StatusCode == http.StatusNotFound {
 		return nil, fmt.Errorf("resource  not found: %v", URL)
 	}
-
-	return objects[0], nil
+	if !isSuccessStatus(response.StatusCode) {
+		return nil, fmt.Errorf("invalid response code: %v, %v", response.Status, URL)
+	}
+	lastModified, err := http.ParseTime(response.Header.Get("Last-Modified"))
+	if err != nil {
+		lastModified = time.Now()
+	}
+	var size = response.ContentLength
+	if size < 0 {
+		size = 0
+	}
+	var objectType = StorageObjectContentType
+	if strings.HasSuffix(URL, "/") {
+		objectType = StorageObjectFolderType
+	}
+	return newHttpFileObject(URL, objectType, nil, lastModified, size), nil
 }
 
 //Download returns reader for downloaded storage object
 func (s *httpStorageService) Download(object Object) (io.Reader, error) {
+	return s.DownloadWithContext(context.Background(), object)
+}
+
+//DownloadWithContext returns reader for downloaded storage object, request is cancelled once context is done
+func (s *httpStorageService) DownloadWithContext(ctx context.Context, object Object) (io.Reader, error) {
 	client, err := newHttpClient()
 	if err != nil {
 		return nil, err
 	}
-	response, err := client.Get(s.addCredentialToURLIfNeeded(object.URL()))
-	content, err := ioutil.ReadAll(response.Body)
+	request, err := http.NewRequest("GET", s.addCredentialToURLIfNeeded(object.URL()), nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := client.Do(request.WithContext(ctx))
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		if err == nil {
+			response.Body.Close()
+		}
+		return nil, ctxErr
+	}
 	if err != nil {
 		return nil, err
 	}
 	defer response.Body.Close()
+	if !isSuccessStatus(response.StatusCode) {
+		return nil, fmt.Errorf("failed to download %v, invalid response code: %v", object.URL(), response.Status)
+	}
+	content, err := ioutil.ReadAll(response.Body)
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return nil, ctxErr
+	}
+	if err != nil {
+		return nil, err
+	}
 	return bytes.NewReader(content), err
 }
 
-//Upload uploads provided reader content for supplied url.
+//Upload is not supported by http storage
 func (s *httpStorageService) Upload(URL string, reader io.Reader) error {
-	return errors.New("unsupported")
+	return fmt.Errorf("upload is not supported by http storage: %v", URL)
+}
+
+//UploadWithOptions uploads provided reader content for supplied url, options are ignored
+func (s *httpStorageService) UploadWithOptions(URL string, reader io.Reader, options UploadOptions) error {
+	return s.Upload(URL, reader)
+}
+
+//UploadWithContext is not supported by http storage
+func (s *httpStorageService) UploadWithContext(ctx context.Context, URL string, reader io.Reader) error {
+	return s.Upload(URL, reader)
+}
+
+//ChecksumMD5 returns MD5 hex digest of storage object content
+func (s *httpStorageService) ChecksumMD5(object Object) (string, error) {
+	return ChecksumMD5(s, object)
+}
+
+//ChecksumSHA256 returns SHA-256 hex digest of storage object content
+func (s *httpStorageService) ChecksumSHA256(object Object) (string, error) {
+	return ChecksumSHA256(s, object)
+}
+
+//DownloadRange returns reader for downloaded storage object content window
+func (s *httpStorageService) DownloadRange(object Object, offset, length int64) (io.Reader, error) {
+	return DownloadRange(s, object, offset, length)
+}
+
+//ListMatch returns objects for supplied url whose base name matches shell pattern
+func (s *httpStorageService) ListMatch(URL, pattern string) ([]Object, error) {
+	return ListMatch(s, URL, pattern)
+}
+
+//ListRecursive returns all objects under supplied url
+func (s *httpStorageService) ListRecursive(URL string) ([]Object, error) {
+	return ListRecursive(s, URL)
+}
+
+//Walk calls visitor with each object under supplied url
+func (s *httpStorageService) Walk(URL string, visitor WalkVisitor) error {
+	return Walk(s, URL, visitor)
+}
+
+//DeleteAll removes passed in storage objects
+func (s *httpStorageService) DeleteAll(objects []Object) error {
+	return DeleteAll(s, objects)
+}
+
+//Copy copies object from source URL to destination URL
+func (s *httpStorageService) Copy(sourceURL, destinationURL string) error {
+	return CopyObject(s, sourceURL, s, destinationURL)
+}
+
+//Move moves object from source URL to destination URL
+func (s *httpStorageService) Move(sourceURL, destinationURL string) error {
+	return MoveObject(s, sourceURL, s, destinationURL)
+}
+
+//CreateFolder is not supported by http storage
+func (s *httpStorageService) CreateFolder(URL string) error {
+	return fmt.Errorf("create folder is not supported by http storage: %v", URL)
 }
 
 func (s *httpStorageService) Register(schema string, service Service) error {
 	return errors.New("unsupported")
 }
 
-//Delete removes passed in storage object
+//Delete is not supported by http storage
 func (s *httpStorageService) Delete(object Object) error {
-	fileName, err := toolbox.FileFromURL(object.URL())
-	if err != nil {
-		return err
-	}
-	return os.Remove(fileName)
+	return fmt.Errorf("delete is not supported by http storage: %v", object.URL())
 }
 
 func (s *httpStorageService) Close() error {
@@ -251,6 +370,8 @@ const HttpsProviderScheme = "https"
 func init() {
 	NewStorageProvider().Registry[HttpsProviderScheme] = httpServiceProvider
 	NewStorageProvider().Registry[HttpProviderScheme] = httpServiceProvider
+	NewStorageProvider().ConfigRegistry[HttpsProviderScheme] = httpServiceConfigProvider
+	NewStorageProvider().ConfigRegistry[HttpProviderScheme] = httpServiceConfigProvider
 
 }
 
@@ -270,3 +391,11 @@ func httpServiceProvider(credentialFile string) (Service, error) {
 	}
 	return NewHttpStorageService(config), nil
 }
+
+func httpServiceConfigProvider(config interface{}) (Service, error) {
+	credential, err := AsCredConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	return NewHttpStorageService(credential), nil
+}
@@ -4,7 +4,11 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/viant/toolbox/storage"
 	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestNewHttpStorageService(t *testing.T) {
@@ -28,3 +32,70 @@ func TestNewHttpStorageService(t *testing.T) {
 
 
 }
+
+func TestHttpStorageService_ReadOnly(t *testing.T) {
+	var lastModified = time.Date(2017, 11, 4, 22, 29, 33, 0, time.UTC)
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		switch request.URL.Path {
+		case "/artifact.txt":
+			writer.Header().Set("Last-Modified", lastModified.Format(http.TimeFormat))
+			writer.Header().Set("Content-Length", "8")
+			writer.Write([]byte("artifact"))
+		case "/redirect":
+			http.Redirect(writer, request, "/artifact.txt", http.StatusFound)
+		case "/error":
+			writer.WriteHeader(http.StatusInternalServerError)
+		default:
+			http.NotFound(writer, request)
+		}
+	}))
+	defer server.Close()
+	service := storage.NewHttpStorageService(nil)
+
+	exists, err := service.Exists(server.URL + "/artifact.txt")
+	assert.Nil(t, err)
+	assert.True(t, exists)
+
+	exists, err = service.Exists(server.URL + "/missing.txt")
+	assert.Nil(t, err)
+	assert.False(t, exists)
+
+	_, err = service.Exists(server.URL + "/error")
+	assert.NotNil(t, err)
+
+	object, err := service.StorageObject(server.URL + "/artifact.txt")
+	if assert.Nil(t, err) {
+		assert.True(t, object.IsContent())
+		assert.Equal(t, int64(8), object.FileInfo().Size())
+		assert.Equal(t, lastModified, object.FileInfo().ModTime().UTC())
+
+		reader, err := service.Download(object)
+		if assert.Nil(t, err) {
+			content, err := ioutil.ReadAll(reader)
+			assert.Nil(t, err)
+			assert.Equal(t, "artifact", string(content))
+		}
+		err = service.Delete(object)
+		if assert.NotNil(t, err) {
+			assert.True(t, strings.Contains(err.Error(), "not supported"))
+		}
+	}
+
+	object, err = service.StorageObject(server.URL + "/redirect")
+	if assert.Nil(t, err) {
+		reader, err := service.Download(object)
+		if assert.Nil(t, err) {
+			content, err := ioutil.ReadAll(reader)
+			assert.Nil(t, err)
+			assert.Equal(t, "artifact", string(content))
+		}
+	}
+
+	_, err = service.StorageObject(server.URL + "/missing.txt")
+	assert.NotNil(t, err)
+
+	err = service.Upload(server.URL+"/artifact.txt", strings.NewReader("abc"))
+	if assert.NotNil(t, err) {
+		assert.True(t, strings.Contains(err.Error(), "not supported"))
+	}
+}
@@ -0,0 +1,239 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+//OperationStats represents call count, error count and total duration of a storage operation
+type OperationStats struct {
+	Calls    int
+	Errors   int
+	Duration time.Duration
+}
+
+//ServiceStats represents instrumented storage service statistics snapshot, operations are keyed by Service method name
+type ServiceStats struct {
+	Operations      map[string]OperationStats
+	Calls           int
+	Errors          int
+	BytesDownloaded int64
+	BytesUploaded   int64
+}
+
+//InstrumentedService represents storage service that records operation statistics
+type InstrumentedService interface {
+	Service
+
+	//Stats returns statistics snapshot
+	Stats() ServiceStats
+}
+
+//countingReader represents a reader that adds number of read bytes to counter
+type countingReader struct {
+	io.Reader
+	counter *int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	atomic.AddInt64(r.counter, int64(n))
+	return n, err
+}
+
+//Close closes underlying reader if it implements io.Closer
+func (r *countingReader) Close() error {
+	if closer, ok := r.Reader.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+//instrumentedStorageService represents a storage service decorator that records calls, errors, durations and transferred bytes
+type instrumentedStorageService struct {
+	Service
+	mutex           *sync.Mutex
+	operations      map[string]*OperationStats
+	bytesDownloaded int64
+	bytesUploaded   int64
+}
+
+func (s *instrumentedStorageService) record(operation string, started time.Time, err error) {
+	var elapsed = time.Since(started)
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	stats, ok := s.operations[operation]
+	if !ok {
+		stats = &OperationStats{}
+		s.operations[operation] = stats
+	}
+	stats.Calls++
+	stats.Duration += elapsed
+	if err != nil {
+		stats.Errors++
+	}
+}
+
+func (s *instrumentedStorageService) downloadReader(reader io.Reader) io.Reader {
+	if reader == nil {
+		return nil
+	}
+	return &countingReader{Reader: reader, counter: &s.bytesDownloaded}
+}
+
+func (s *instrumentedStorageService) uploadReader(reader io.Reader) io.Reader {
+	return &countingReader{Reader: reader, counter: &s.bytesUploaded}
+}
+
+//Stats returns statistics snapshot
+func (s *instrumentedStorageService) Stats() ServiceStats {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	var result = ServiceStats{
+		Operations:      make(map[string]OperationStats),
+		BytesDownloaded: atomic.LoadInt64(&s.bytesDownloaded),
+		BytesUploaded:   atomic.LoadInt64(&s.bytesUploaded),
+	}
+	for operation, stats := range s.operations {
+		result.Operations[operation] = *stats
+		result.Calls += stats.Calls
+		result.Errors += stats.Errors
+	}
+	return result
+}
+
+//List returns a list of object for supplied url
+func (s *instrumentedStorageService) List(URL string) (result []Object, err error) {
+	defer func(started time.Time) { s.record("List", started, err) }(time.Now())
+	return s.Service.List(URL)
+}
+
+//ListMatch returns objects for supplied url whose base name matches shell pattern
+func (s *instrumentedStorageService) ListMatch(URL, pattern string) (result []Object, err error) {
+	defer func(started time.Time) { s.record("ListMatch", started, err) }(time.Now())
+	return s.Service.ListMatch(URL, pattern)
+}
+
+//ListRecursive returns all objects under supplied url
+func (s *instrumentedStorageService) ListRecursive(URL string) (result []Object, err error) {
+	defer func(started time.Time) { s.record("ListRecursive", started, err) }(time.Now())
+	return s.Service.ListRecursive(URL)
+}
+
+//Walk calls visitor with each object under supplied url
+func (s *instrumentedStorageService) Walk(URL string, visitor WalkVisitor) (err error) {
+	defer func(started time.Time) { s.record("Walk", started, err) }(time.Now())
+	return s.Service.Walk(URL, visitor)
+}
+
+//Exists returns true if resource exists
+func (s *instrumentedStorageService) Exists(URL string) (result bool, err error) {
+	defer func(started time.Time) { s.record("Exists", started, err) }(time.Now())
+	return s.Service.Exists(URL)
+}
+
+//ExistsPrefix returns true if at least one object URL starts with supplied URL
+func (s *instrumentedStorageService) ExistsPrefix(URL string) (result bool, err error) {
+	defer func(started time.Time) { s.record("ExistsPrefix", started, err) }(time.Now())
+	return s.Service.ExistsPrefix(URL)
+}
+
+//StorageObject returns a Object for supplied url
+func (s *instrumentedStorageService) StorageObject(URL string) (result Object, err error) {
+	defer func(started time.Time) { s.record("StorageObject", started, err) }(time.Now())
+	return s.Service.StorageObject(URL)
+}
+
+//Download returns reader for downloaded storage object, downloaded bytes are counted as they are read
+func (s *instrumentedStorageService) Download(object Object) (result io.Reader, err error) {
+	defer func(started time.Time) { s.record("Download", started, err) }(time.Now())
+	result, err = s.Service.Download(object)
+	return s.downloadReader(result), err
+}
+
+//DownloadRange returns reader for downloaded storage object content window
+func (s *instrumentedStorageService) DownloadRange(object Object, offset, length int64) (result io.Reader, err error) {
+	defer func(started time.Time) { s.record("DownloadRange", started, err) }(time.Now())
+	result, err = s.Service.DownloadRange(object, offset, length)
+	return s.downloadReader(result), err
+}
+
+//DownloadWithContext returns reader for downloaded storage object
+func (s *instrumentedStorageService) DownloadWithContext(ctx context.Context, object Object) (result io.Reader, err error) {
+	defer func(started time.Time) { s.record("DownloadWithContext", started, err) }(time.Now())
+	result, err = s.Service.DownloadWithContext(ctx, object)
+	return s.downloadReader(result), err
+}
+
+//ChecksumMD5 returns hex encoded MD5 digest of storage object content
+func (s *instrumentedStorageService) ChecksumMD5(object Object) (result string, err error) {
+	defer func(started time.Time) { s.record("ChecksumMD5", started, err) }(time.Now())
+	return s.Service.ChecksumMD5(object)
+}
+
+//ChecksumSHA256 returns hex encoded SHA-256 digest of storage object content
+func (s *instrumentedStorageService) ChecksumSHA256(object Object) (result string, err error) {
+	defer func(started time.Time) { s.record("ChecksumSHA256", started, err) }(time.Now())
+	return s.Service.ChecksumSHA256(object)
+}
+
+//CreateFolder creates folder hierarchy for supplied URL
+func (s *instrumentedStorageService) CreateFolder(URL string) (err error) {
+	defer func(started time.Time) { s.record("CreateFolder", started, err) }(time.Now())
+	return s.Service.CreateFolder(URL)
+}
+
+//Upload uploads provided reader content for supplied url, uploaded bytes are counted as delegate reads them
+func (s *instrumentedStorageService) Upload(URL string, reader io.Reader) (err error) {
+	defer func(started time.Time) { s.record("Upload", started, err) }(time.Now())
+	return s.Service.Upload(URL, s.uploadReader(reader))
+}
+
+//UploadWithOptions uploads provided reader content for supplied url with options
+func (s *instrumentedStorageService) UploadWithOptions(URL string, reader io.Reader, options UploadOptions) (err error) {
+	defer func(started time.Time) { s.record("UploadWithOptions", started, err) }(time.Now())
+	return s.Service.UploadWithOptions(URL, s.uploadReader(reader), options)
+}
+
+//UploadWithContext uploads provided reader content for supplied url
+func (s *instrumentedStorageService) UploadWithContext(ctx context.Context, URL string, reader io.Reader) (err error) {
+	defer func(started time.Time) { s.record("UploadWithContext", started, err) }(time.Now())
+	return s.Service.UploadWithContext(ctx, URL, s.uploadReader(reader))
+}
+
+//Delete removes passed in storage object
+func (s *instrumentedStorageService) Delete(object Object) (err error) {
+	defer func(started time.Time) { s.record("Delete", started, err) }(time.Now())
+	return s.Service.Delete(object)
+}
+
+//DeleteAll removes passed in storage objects
+func (s *instrumentedStorageService) DeleteAll(objects []Object) (err error) {
+	defer func(started time.Time) { s.record("DeleteAll", started, err) }(time.Now())
+	return s.Service.DeleteAll(objects)
+}
+
+//Copy copies object from source URL to destination URL
+func (s *instrumentedStorageService) Copy(sourceURL, destinationURL string) (err error) {
+	defer func(started time.Time) { s.record("Copy", started, err) }(time.Now())
+	return s.Service.Copy(sourceURL, destinationURL)
+}
+
+//Move moves object from source URL to destination URL
+func (s *instrumentedStorageService) Move(sourceURL, destinationURL string) (err error) {
+	defer func(started time.Time) { s.record("Move", started, err) }(time.Now())
+	return s.Service.Move(sourceURL, destinationURL)
+}
+
+//NewInstrumentedService creates a new storage service that records per operation call count, error count and duration,
+//as well as bytes read from downloaded readers and bytes uploaded through delegate service, it is safe for concurrent use
+func NewInstrumentedService(delegate Service) InstrumentedService {
+	return &instrumentedStorageService{
+		Service:    delegate,
+		mutex:      &sync.Mutex{},
+		operations: make(map[string]*OperationStats),
+	}
+}
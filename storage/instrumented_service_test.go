@@ -0,0 +1,67 @@
+package storage_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/toolbox/storage"
+	"io/ioutil"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestNewInstrumentedService(t *testing.T) {
+	service := storage.NewInstrumentedService(storage.NewMemoryServiceWithLimit(0))
+	assert.Nil(t, service.Upload("mem:///instrumented_test/file1.txt", strings.NewReader("abc")))
+	assert.Nil(t, service.Upload("mem:///instrumented_test/file2.txt", strings.NewReader("12345")))
+
+	objects, err := service.List("mem:///instrumented_test")
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(objects))
+
+	object, err := service.StorageObject("mem:///instrumented_test/file1.txt")
+	if assert.Nil(t, err) {
+		reader, err := service.Download(object)
+		assert.Nil(t, err)
+		content, err := ioutil.ReadAll(reader)
+		assert.Nil(t, err)
+		assert.Equal(t, "abc", string(content))
+		assert.Nil(t, service.Delete(object))
+	}
+	_, err = service.StorageObject("mem:///instrumented_test/missing.txt")
+	assert.NotNil(t, err)
+
+	stats := service.Stats()
+	assert.Equal(t, 7, stats.Calls)
+	assert.Equal(t, 1, stats.Errors)
+	assert.EqualValues(t, 8, stats.BytesUploaded)
+	assert.EqualValues(t, 3, stats.BytesDownloaded)
+	assert.Equal(t, 2, stats.Operations["Upload"].Calls)
+	assert.Equal(t, 1, stats.Operations["List"].Calls)
+	assert.Equal(t, 2, stats.Operations["StorageObject"].Calls)
+	assert.Equal(t, 1, stats.Operations["StorageObject"].Errors)
+	assert.Equal(t, 1, stats.Operations["Download"].Calls)
+	assert.Equal(t, 1, stats.Operations["Delete"].Calls)
+
+	//snapshot is not affected by subsequent calls
+	_, _ = service.Exists("mem:///instrumented_test/file2.txt")
+	assert.Equal(t, 0, stats.Operations["Exists"].Calls)
+	assert.Equal(t, 1, service.Stats().Operations["Exists"].Calls)
+}
+
+func TestInstrumentedService_Concurrent(t *testing.T) {
+	service := storage.NewInstrumentedService(storage.NewMemoryServiceWithLimit(0))
+	assert.Nil(t, service.Upload("mem:///instrumented_concurrent_test/file.txt", strings.NewReader("abc")))
+	var waitGroup sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			for j := 0; j < 10; j++ {
+				_, _ = service.Exists("mem:///instrumented_concurrent_test/file.txt")
+				_ = service.Stats()
+			}
+		}()
+	}
+	waitGroup.Wait()
+	assert.Equal(t, 100, service.Stats().Operations["Exists"].Calls)
+}
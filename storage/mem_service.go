@@ -2,11 +2,18 @@ package storage
 
 import (
 	"bytes"
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
+	"fmt"
+	"hash"
 	"io"
 	"io/ioutil"
 	"net/url"
 	"os"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -20,15 +27,42 @@ var folderMode, _ = NewFileMode("drwxrwxrwx")
 //MemoryRoot represents memory root storage
 var MemoryRoot = newMemoryFolder("mem:///", NewFileInfo("/", 102, folderMode, time.Now(), true))
 
+//memoryRootMutex guards MemoryRoot tree shared by all services created with NewMemoryService
+var memoryRootMutex = &sync.RWMutex{}
+
 //Service represents memory storage service intended for testing
 type memoryStorageService struct {
-	root *MemoryFolder
+	root     *MemoryFolder
+	maxBytes int64
+	mutex    *sync.RWMutex
+}
+
+//LimitedMemoryService represents memory storage service with max stored bytes limit
+type LimitedMemoryService interface {
+	Service
+
+	//Usage returns currently stored bytes
+	Usage() int64
+
+	//Limit returns max stored bytes
+	Limit() int64
 }
 
 type MemoryFile struct {
 	name     string
 	fileInfo os.FileInfo
 	content  []byte
+	options  UploadOptions
+}
+
+//Options returns upload options the file was stored with
+func (f *MemoryFile) Options() UploadOptions {
+	return f.options
+}
+
+//ContentType returns content type the file was stored with
+func (f *MemoryFile) ContentType() string {
+	return f.options.ContentType
 }
 
 func (f *MemoryFile) Object() Object {
@@ -59,6 +93,89 @@ func (f *MemoryFolder) Objects() []Object {
 	return result
 }
 
+func (f *MemoryFolder) walk(result *[]Object) {
+	f.mutext.RLock()
+	defer f.mutext.RUnlock()
+	for _, folder := range f.folders {
+		*result = append(*result, folder.Object())
+		folder.walk(result)
+	}
+	for _, file := range f.files {
+		*result = append(*result, file.Object())
+	}
+}
+
+//visit calls visitor with each nested folder and file in name order, children are snapshotted so that visitor can modify the storage
+func (f *MemoryFolder) visit(visitor WalkVisitor) error {
+	f.mutext.RLock()
+	var folderNames = make([]string, 0, len(f.folders))
+	for name := range f.folders {
+		folderNames = append(folderNames, name)
+	}
+	var fileNames = make([]string, 0, len(f.files))
+	for name := range f.files {
+		fileNames = append(fileNames, name)
+	}
+	f.mutext.RUnlock()
+	sort.Strings(folderNames)
+	sort.Strings(fileNames)
+	for _, name := range folderNames {
+		f.mutext.RLock()
+		folder, ok := f.folders[name]
+		f.mutext.RUnlock()
+		if !ok {
+			continue
+		}
+		if err := visitor(folder.Object()); err != nil {
+			return err
+		}
+		if err := folder.visit(visitor); err != nil {
+			return err
+		}
+	}
+	for _, name := range fileNames {
+		f.mutext.RLock()
+		file, ok := f.files[name]
+		f.mutext.RUnlock()
+		if !ok {
+			continue
+		}
+		if err := visitor(file.Object()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *MemoryFolder) hasPrefix(prefix string) bool {
+	f.mutext.RLock()
+	defer f.mutext.RUnlock()
+	for _, file := range f.files {
+		if strings.HasPrefix(urlPath(file.name), prefix) {
+			return true
+		}
+	}
+	for _, folder := range f.folders {
+		if strings.HasPrefix(urlPath(folder.name), prefix) || folder.hasPrefix(prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *MemoryFolder) size() int64 {
+	f.mutext.RLock()
+	defer f.mutext.RUnlock()
+	var result int64
+	for _, folder := range f.folders {
+		result += folder.size()
+	}
+	for _, file := range f.files {
+		result += int64(len(file.content))
+	}
+	return result
+}
+
 func newMemoryFolder(name string, info os.FileInfo) *MemoryFolder {
 	return &MemoryFolder{
 		name:     name,
@@ -97,6 +214,12 @@ func (s *memoryStorageService) getPath(URL string) (string, error) {
 
 //List returns a list of object for supplied url
 func (s *memoryStorageService) List(URL string) ([]Object, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.list(URL)
+}
+
+func (s *memoryStorageService) list(URL string) ([]Object, error) {
 	path, err := s.getPath(URL)
 	if err != nil {
 		return nil, err
@@ -104,9 +227,6 @@ func (s *memoryStorageService) List(URL string) ([]Object, error) {
 	if path == "/" {
 		return s.root.Objects(), nil
 	}
-
-
-
 	var pathFragments = strings.Split(path, "/")
 	node, err := s.getFolder(pathFragments)
 	if err != nil {
@@ -124,6 +244,85 @@ func (s *memoryStorageService) List(URL string) ([]Object, error) {
 	return []Object{}, nil
 }
 
+//ListMatch returns objects for supplied url whose base name matches shell pattern
+func (s *memoryStorageService) ListMatch(URL, pattern string) ([]Object, error) {
+	return ListMatch(s, URL, pattern)
+}
+
+//ListRecursive returns all files and folders stored under supplied url
+func (s *memoryStorageService) ListRecursive(URL string) ([]Object, error) {
+	path, err := s.getPath(URL)
+	if err != nil {
+		return nil, err
+	}
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	var result = make([]Object, 0)
+	var folder = s.root
+	if path != "/" {
+		var pathFragments = strings.Split(path, "/")
+		node, err := s.getFolder(pathFragments)
+		if err != nil {
+			return nil, err
+		}
+		var pathLeaf = pathFragments[len(pathFragments)-1]
+		if memoryFile, ok := node.files[pathLeaf]; ok {
+			return []Object{memoryFile.Object()}, nil
+		}
+		var ok bool
+		if folder, ok = node.folders[pathLeaf]; !ok {
+			return result, nil
+		}
+	}
+	folder.walk(&result)
+	sortObjects(result)
+	return result, nil
+}
+
+//Walk calls visitor with each file and folder stored under supplied url in name order
+func (s *memoryStorageService) Walk(URL string, visitor WalkVisitor) error {
+	path, err := s.getPath(URL)
+	if err != nil {
+		return err
+	}
+	file, folder, err := s.walkStart(path)
+	if err != nil {
+		return err
+	}
+	if file != nil {
+		if err = visitor(file.Object()); err != nil && err != StopWalkError {
+			return err
+		}
+		return nil
+	}
+	if folder == nil {
+		return nil
+	}
+	if err = folder.visit(visitor); err != nil && err != StopWalkError {
+		return err
+	}
+	return nil
+}
+
+//walkStart returns file or folder for supplied path, the lock is released before visitor is called so that visitor can modify the storage
+func (s *memoryStorageService) walkStart(path string) (*MemoryFile, *MemoryFolder, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	if path == "/" {
+		return nil, s.root, nil
+	}
+	var pathFragments = strings.Split(path, "/")
+	node, err := s.getFolder(pathFragments)
+	if err != nil {
+		return nil, nil, err
+	}
+	var pathLeaf = pathFragments[len(pathFragments)-1]
+	if memoryFile, ok := node.files[pathLeaf]; ok {
+		return memoryFile, nil, nil
+	}
+	return nil, node.folders[pathLeaf], nil
+}
+
 //Exists returns true if resource exists
 func (s *memoryStorageService) Exists(URL string) (bool, error) {
 	objects, err := s.List(URL)
@@ -133,6 +332,16 @@ func (s *memoryStorageService) Exists(URL string) (bool, error) {
 	return len(objects) > 0, nil
 }
 
+//ExistsPrefix returns true if at least one stored object path starts with supplied URL path
+func (s *memoryStorageService) ExistsPrefix(URL string) (bool, error) {
+	if _, err := url.Parse(URL); err != nil {
+		return false, err
+	}
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.root.hasPrefix(prefixPath(URL)), nil
+}
+
 func (s *memoryStorageService) Close() error {
 	return nil
 }
@@ -155,6 +364,8 @@ func (s *memoryStorageService) Download(object Object) (io.Reader, error) {
 	if err != nil {
 		return nil, err
 	}
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
 	var pathFragments = strings.Split(urlPath, "/")
 	node, err := s.getFolder(pathFragments)
 	if err != nil {
@@ -167,24 +378,83 @@ func (s *memoryStorageService) Download(object Object) (io.Reader, error) {
 	return nil, noSuchFileOrDirectoryError
 }
 
-//Upload uploads provided reader content for supplied url.
-func (s *memoryStorageService) Upload(URL string, reader io.Reader) error {
-	urlPath, err := s.getPath(URL)
+func (s *memoryStorageService) checksum(object Object, hasher hash.Hash) (string, error) {
+	var urlPath, err = s.getPath(object.URL())
 	if err != nil {
-		return err
+		return "", err
 	}
-	content, err := ioutil.ReadAll(reader)
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	var pathFragments = strings.Split(urlPath, "/")
+	node, err := s.getFolder(pathFragments)
 	if err != nil {
-		return err
+		return "", err
 	}
-	var node = s.root
+	memoryFile, ok := node.files[pathFragments[len(pathFragments)-1]]
+	if !ok {
+		return "", noSuchFileOrDirectoryError
+	}
+	hasher.Write(memoryFile.content)
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+//DownloadWithContext returns reader for downloaded storage object, transfer is aborted once context is done
+func (s *memoryStorageService) DownloadWithContext(ctx context.Context, object Object) (io.Reader, error) {
+	return DownloadWithContext(ctx, s, object)
+}
+
+//UploadWithContext uploads provided reader content for supplied url, transfer is aborted once context is done
+func (s *memoryStorageService) UploadWithContext(ctx context.Context, URL string, reader io.Reader) error {
+	return UploadWithContext(ctx, s, URL, reader)
+}
+
+//ChecksumMD5 returns MD5 hex digest of stored content
+func (s *memoryStorageService) ChecksumMD5(object Object) (string, error) {
+	return s.checksum(object, md5.New())
+}
+
+//ChecksumSHA256 returns SHA-256 hex digest of stored content
+func (s *memoryStorageService) ChecksumSHA256(object Object) (string, error) {
+	return s.checksum(object, sha256.New())
+}
+
+//DownloadRange returns reader for length bytes of storage object starting at offset
+func (s *memoryStorageService) DownloadRange(object Object, offset, length int64) (io.Reader, error) {
+	var urlPath, err = s.getPath(object.URL())
+	if err != nil {
+		return nil, err
+	}
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
 	var pathFragments = strings.Split(urlPath, "/")
-	for i := 1; i+1 < len(pathFragments); i++ {
+	node, err := s.getFolder(pathFragments)
+	if err != nil {
+		return nil, err
+	}
+	memoryFile, ok := node.files[pathFragments[len(pathFragments)-1]]
+	if !ok {
+		return nil, noSuchFileOrDirectoryError
+	}
+	var size = int64(len(memoryFile.content))
+	if err = checkDownloadRange(object, size, offset); err != nil {
+		return nil, err
+	}
+	var end = size
+	if length > 0 && offset+length < size {
+		end = offset + length
+	}
+	return bytes.NewReader(memoryFile.content[offset:end]), nil
+}
+
+//createFolders returns folder for supplied path fragments (starting with root empty fragment), missing folders are created
+func (s *memoryStorageService) createFolders(pathFragments []string) *MemoryFolder {
+	var node = s.root
+	for i := 1; i < len(pathFragments); i++ {
 		pathFragment := pathFragments[i]
 		if subFolder, ok := node.folders[pathFragment]; ok {
 			node = subFolder
-		} else  {
-			var folderURL =  MemoryProviderScheme + "://" + strings.Join(pathFragments[:i+1], "/")
+		} else {
+			var folderURL = MemoryProviderScheme + "://" + strings.Join(pathFragments[:i+1], "/")
 			var folderInfo = NewFileInfo(pathFragment, 102, folderMode, time.Now(), true)
 			newFolder := newMemoryFolder(folderURL, folderInfo)
 			node.mutext.Lock()
@@ -193,15 +463,80 @@ func (s *memoryStorageService) Upload(URL string, reader io.Reader) error {
 			node = newFolder
 		}
 	}
+	return node
+}
+
+//CreateFolder creates folder with all missing parent folders for supplied url, it returns an error if a file with the same URL exists
+func (s *memoryStorageService) CreateFolder(URL string) error {
+	folderPath, err := s.getPath(URL)
+	if err != nil {
+		return err
+	}
+	if folderPath == "/" {
+		return nil
+	}
+	if object, err := s.StorageObject(URL); err == nil && object.IsContent() {
+		return fmt.Errorf("failed to create folder %v: file already exists", URL)
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.createFolders(strings.Split(folderPath, "/"))
+	return nil
+}
 
+//Upload uploads provided reader content for supplied url.
+func (s *memoryStorageService) Upload(URL string, reader io.Reader) error {
+	return s.UploadWithOptions(URL, reader, UploadOptions{})
+}
 
+//UploadWithOptions uploads provided reader content for supplied url, options are stored alongside content
+func (s *memoryStorageService) UploadWithOptions(URL string, reader io.Reader, options UploadOptions) error {
+	URL, reader = CompressedUpload(URL, reader, options)
+	urlPath, err := s.getPath(URL)
+	if err != nil {
+		return err
+	}
+	content, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.maxBytes > 0 {
+		var usage = s.root.size()
+		if objects, err := s.list(URL); err == nil && len(objects) > 0 && objects[0].IsContent() {
+			usage -= objects[0].FileInfo().Size()
+		}
+		if usage+int64(len(content)) > s.maxBytes {
+			return fmt.Errorf("memory storage limit exceeded: failed to upload %v bytes to %v, used %v of %v bytes", len(content), URL, usage, s.maxBytes)
+		}
+	}
+	var pathFragments = strings.Split(urlPath, "/")
+	var node = s.createFolders(pathFragments[:len(pathFragments)-1])
 	var pathLeaf = pathFragments[len(pathFragments)-1]
 	fileInfo := NewFileInfo(pathLeaf, int64(len(content)), fileMode, time.Now(), false)
-	var memoryFile = &MemoryFile{name: URL, content: content, fileInfo: fileInfo}
+	var memoryFile = &MemoryFile{name: URL, content: content, fileInfo: fileInfo, options: options}
+	node.mutext.Lock()
 	node.files[fileInfo.Name()] = memoryFile
+	node.mutext.Unlock()
 	return nil
 }
 
+//DeleteAll removes passed in storage objects
+func (s *memoryStorageService) DeleteAll(objects []Object) error {
+	return DeleteAll(s, objects)
+}
+
+//Copy copies object from source URL to destination URL
+func (s *memoryStorageService) Copy(sourceURL, destinationURL string) error {
+	return CopyObject(s, sourceURL, s, destinationURL)
+}
+
+//Move moves object from source URL to destination URL
+func (s *memoryStorageService) Move(sourceURL, destinationURL string) error {
+	return MoveObject(s, sourceURL, s, destinationURL)
+}
+
 func (s *memoryStorageService) Register(schema string, service Service) error {
 	return errors.New("unsupported")
 }
@@ -212,26 +547,52 @@ func (s *memoryStorageService) Delete(object Object) error {
 	if err != nil {
 		return err
 	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
 	var pathFragments = strings.Split(urlPath, "/")
 	node, err := s.getFolder(pathFragments)
 	if err != nil {
 		return err
 	}
 	var pathLeaf = pathFragments[len(pathFragments)-1]
+	node.mutext.Lock()
+	defer node.mutext.Unlock()
 	if _, ok := node.files[pathLeaf]; ok {
 		delete(node.files, pathLeaf)
 		return nil
 	}
-	if _, ok := node.files[pathLeaf]; ok {
+	if _, ok := node.folders[pathLeaf]; ok {
 		delete(node.folders, pathLeaf)
 		return nil
 	}
 	return noSuchFileOrDirectoryError
 }
 
+//Usage returns total size of stored content
+func (s *memoryStorageService) Usage() int64 {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.root.size()
+}
+
+//Limit returns max stored bytes, 0 means no limit
+func (s *memoryStorageService) Limit() int64 {
+	return s.maxBytes
+}
+
 func NewMemoryService() Service {
 	return &memoryStorageService{
-		root: MemoryRoot,
+		root:  MemoryRoot,
+		mutex: memoryRootMutex,
+	}
+}
+
+//NewMemoryServiceWithLimit creates a memory storage service with its own root, uploads that would store more than maxBytes in total are rejected, zero maxBytes disables the limit
+func NewMemoryServiceWithLimit(maxBytes int64) LimitedMemoryService {
+	return &memoryStorageService{
+		root:     newMemoryFolder("mem:///", NewFileInfo("/", 102, folderMode, time.Now(), true)),
+		maxBytes: maxBytes,
+		mutex:    &sync.RWMutex{},
 	}
 }
 
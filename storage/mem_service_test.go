@@ -1,12 +1,15 @@
 package storage_test
 
 import (
+	"fmt"
 	"github.com/stretchr/testify/assert"
 	"github.com/viant/toolbox/storage"
 	"io/ioutil"
 	"strings"
+	"sync"
 	"testing"
 	"github.com/viant/toolbox"
+	"time"
 )
 
 func Test_NewMemoryService(t *testing.T) {
@@ -132,3 +135,351 @@ func TestMemCopy(t *testing.T) {
 
 
 }
+
+func TestMemoryService_ListRecursive(t *testing.T) {
+	service := storage.NewMemoryService()
+	var files = []string{
+		"mem:///recursive_test/file1.txt",
+		"mem:///recursive_test/sub/file2.txt",
+		"mem:///recursive_test/sub/nested/file3.txt",
+	}
+	for _, URL := range files {
+		err := service.Upload(URL, strings.NewReader("abc"))
+		assert.Nil(t, err)
+	}
+	objects, err := service.ListRecursive("mem:///recursive_test")
+	assert.Nil(t, err)
+	var actual = make(map[string]bool)
+	for _, object := range objects {
+		actual[object.URL()] = object.IsFolder()
+	}
+	assert.EqualValues(t, map[string]bool{
+		"mem:///recursive_test/file1.txt":            false,
+		"mem:///recursive_test/sub":                  true,
+		"mem:///recursive_test/sub/file2.txt":        false,
+		"mem:///recursive_test/sub/nested":           true,
+		"mem:///recursive_test/sub/nested/file3.txt": false,
+	}, actual)
+
+	objects, err = service.ListRecursive("mem:///recursive_test/sub/file2.txt")
+	if assert.Nil(t, err) && assert.Equal(t, 1, len(objects)) {
+		assert.True(t, objects[0].IsContent())
+	}
+}
+
+func TestMemoryService_Walk(t *testing.T) {
+	service := storage.NewMemoryService()
+	for _, URL := range []string{
+		"mem:///walk_test/file1.txt",
+		"mem:///walk_test/sub/file2.txt",
+		"mem:///walk_test/sub/nested/file3.txt",
+	} {
+		err := service.Upload(URL, strings.NewReader("abc"))
+		assert.Nil(t, err)
+	}
+	var visited = make([]string, 0)
+	err := service.Walk("mem:///walk_test", func(object storage.Object) error {
+		visited = append(visited, object.URL())
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{
+		"mem:///walk_test/sub",
+		"mem:///walk_test/sub/nested",
+		"mem:///walk_test/sub/nested/file3.txt",
+		"mem:///walk_test/sub/file2.txt",
+		"mem:///walk_test/file1.txt",
+	}, visited)
+
+	{ //early termination
+		var count = 0
+		err := service.Walk("mem:///walk_test", func(object storage.Object) error {
+			count++
+			if count == 2 {
+				return storage.StopWalkError
+			}
+			return nil
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, 2, count)
+	}
+	{ //visitor error
+		err := service.Walk("mem:///walk_test", func(object storage.Object) error {
+			return fmt.Errorf("test error")
+		})
+		assert.NotNil(t, err)
+	}
+	{ //generic implementation based on List
+		var count = 0
+		err := storage.Walk(service, "mem:///walk_test", func(object storage.Object) error {
+			count++
+			return nil
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, len(visited), count)
+
+		count = 0
+		err = storage.Walk(service, "mem:///walk_test", func(object storage.Object) error {
+			count++
+			return storage.StopWalkError
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, 1, count)
+	}
+	{ //content URL
+		visited = visited[:0]
+		err := service.Walk("mem:///walk_test/sub/file2.txt", func(object storage.Object) error {
+			visited = append(visited, object.URL())
+			return nil
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"mem:///walk_test/sub/file2.txt"}, visited)
+	}
+}
+
+func TestMemoryService_CreateFolder(t *testing.T) {
+	service := storage.NewMemoryService()
+	err := service.CreateFolder("mem:///create_folder_test/sub/nested")
+	assert.Nil(t, err)
+	objects, err := service.ListRecursive("mem:///create_folder_test")
+	assert.Nil(t, err)
+	var actual = make(map[string]bool)
+	for _, object := range objects {
+		actual[object.URL()] = object.IsFolder()
+	}
+	assert.EqualValues(t, map[string]bool{
+		"mem:///create_folder_test/sub":        true,
+		"mem:///create_folder_test/sub/nested": true,
+	}, actual)
+
+	//existing folder
+	assert.Nil(t, service.CreateFolder("mem:///create_folder_test/sub/"))
+	assert.Nil(t, service.Upload("mem:///create_folder_test/sub/file.txt", strings.NewReader("abc")))
+	objects, err = service.List("mem:///create_folder_test/sub")
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(objects))
+
+	err = service.CreateFolder("mem:///create_folder_test/sub/file.txt")
+	assert.NotNil(t, err)
+}
+
+func TestMemoryService_DownloadRange(t *testing.T) {
+	service := storage.NewMemoryService()
+	var URL = "mem:///range_test/data.txt"
+	err := service.Upload(URL, strings.NewReader("0123456789"))
+	assert.Nil(t, err)
+	object, err := service.StorageObject(URL)
+	if !assert.Nil(t, err) {
+		return
+	}
+	var useCases = []struct {
+		offset, length int64
+		expected       string
+	}{
+		{0, 3, "012"},
+		{4, 2, "45"},
+		{7, 0, "789"},
+		{8, 10, "89"},
+		{10, 0, ""},
+	}
+	for _, useCase := range useCases {
+		reader, err := service.DownloadRange(object, useCase.offset, useCase.length)
+		if assert.Nil(t, err) {
+			content, err := ioutil.ReadAll(reader)
+			assert.Nil(t, err)
+			assert.Equal(t, useCase.expected, string(content))
+		}
+	}
+	_, err = service.DownloadRange(object, 11, 0)
+	assert.NotNil(t, err)
+	_, err = service.DownloadRange(object, -1, 0)
+	assert.NotNil(t, err)
+}
+
+func TestMemoryService_UploadWithOptions(t *testing.T) {
+	service := storage.NewMemoryService()
+	var URL = "mem:///options_test/data.json"
+	var options = storage.UploadOptions{
+		ContentType:  "application/json",
+		CacheControl: "no-cache",
+		Metadata:     map[string]string{"owner": "etl"},
+	}
+	err := service.UploadWithOptions(URL, strings.NewReader("{}"), options)
+	assert.Nil(t, err)
+	object, err := service.StorageObject(URL)
+	if assert.Nil(t, err) {
+		memoryFile, ok := object.(*storage.AbstractObject).Source.(*storage.MemoryFile)
+		if assert.True(t, ok) {
+			assert.EqualValues(t, options, memoryFile.Options())
+		}
+		reader, err := service.Download(object)
+		if assert.Nil(t, err) {
+			content, err := ioutil.ReadAll(reader)
+			assert.Nil(t, err)
+			assert.Equal(t, "{}", string(content))
+		}
+	}
+
+	err = service.Upload(URL, strings.NewReader("[]"))
+	assert.Nil(t, err)
+	object, err = service.StorageObject(URL)
+	if assert.Nil(t, err) {
+		memoryFile := object.(*storage.AbstractObject).Source.(*storage.MemoryFile)
+		assert.EqualValues(t, storage.UploadOptions{}, memoryFile.Options())
+	}
+}
+
+func TestNewMemoryServiceWithLimit_Isolated(t *testing.T) {
+	service := storage.NewMemoryServiceWithLimit(0)
+	assert.Nil(t, service.Upload("mem:///isolated_test/file.txt", strings.NewReader("abc")))
+	exists, err := service.Exists("mem:///isolated_test/file.txt")
+	assert.Nil(t, err)
+	assert.True(t, exists)
+	exists, _ = storage.NewMemoryService().Exists("mem:///isolated_test/file.txt")
+	assert.False(t, exists)
+	exists, _ = storage.NewMemoryServiceWithLimit(0).Exists("mem:///isolated_test/file.txt")
+	assert.False(t, exists)
+}
+
+func TestNewMemoryServiceWithLimit(t *testing.T) {
+	service := storage.NewMemoryServiceWithLimit(10)
+	assert.Equal(t, int64(10), service.Limit())
+
+	err := service.Upload("mem:///limit/file1.txt", strings.NewReader("12345"))
+	assert.Nil(t, err)
+	err = service.Upload("mem:///limit/sub/file2.txt", strings.NewReader("12345"))
+	assert.Nil(t, err)
+	assert.Equal(t, int64(10), service.Usage())
+
+	err = service.Upload("mem:///limit/file3.txt", strings.NewReader("1"))
+	if assert.NotNil(t, err) {
+		assert.True(t, strings.Contains(err.Error(), "limit exceeded"))
+	}
+	exists, err := service.Exists("mem:///limit/file3.txt")
+	assert.Nil(t, err)
+	assert.False(t, exists)
+
+	err = service.Upload("mem:///limit/file1.txt", strings.NewReader("abc"))
+	assert.Nil(t, err, "overwrite should account for replaced content")
+	assert.Equal(t, int64(8), service.Usage())
+
+	object, err := service.StorageObject("mem:///limit/sub/file2.txt")
+	if assert.Nil(t, err) {
+		err = service.Delete(object)
+		assert.Nil(t, err)
+	}
+	assert.Equal(t, int64(3), service.Usage())
+	err = service.Upload("mem:///limit/file3.txt", strings.NewReader("1234567"))
+	assert.Nil(t, err)
+
+	exists, _ = storage.NewMemoryService().Exists("mem:///limit/file3.txt")
+	assert.False(t, exists, "limited service should use its own root")
+}
+
+func TestNewMemoryServiceWithLimit_Concurrent(t *testing.T) {
+	service := storage.NewMemoryServiceWithLimit(10)
+	var waitGroup sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		waitGroup.Add(1)
+		go func(i int) {
+			defer waitGroup.Done()
+			_ = service.Upload(fmt.Sprintf("mem:///limit_concurrent/file%v.txt", i), strings.NewReader("12345"))
+		}(i)
+	}
+	waitGroup.Wait()
+	assert.Equal(t, int64(10), service.Usage())
+	objects, err := service.ListRecursive("mem:///limit_concurrent")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(objects))
+}
+
+func TestMemoryService_DeleteFolder(t *testing.T) {
+	service := storage.NewMemoryServiceWithLimit(0)
+	err := service.Upload("mem:///delete_folder/sub/file.txt", strings.NewReader("abc"))
+	assert.Nil(t, err)
+	object, err := service.StorageObject("mem:///delete_folder/sub")
+	if assert.Nil(t, err) {
+		assert.True(t, object.IsFolder())
+		assert.Nil(t, service.Delete(object))
+	}
+	exists, _ := service.Exists("mem:///delete_folder/sub/file.txt")
+	assert.False(t, exists)
+	exists, err = service.Exists("mem:///delete_folder/sub")
+	assert.Nil(t, err)
+	assert.False(t, exists)
+	assert.Equal(t, int64(0), service.Usage())
+}
+
+func TestMemoryService_DeleteConcurrent(t *testing.T) {
+	service := storage.NewMemoryServiceWithLimit(100)
+	var waitGroup sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		waitGroup.Add(2)
+		var URL = fmt.Sprintf("mem:///delete_concurrent/file%v.txt", i)
+		go func() {
+			defer waitGroup.Done()
+			if err := service.Upload(URL, strings.NewReader("12345")); err != nil {
+				return
+			}
+			if object, err := service.StorageObject(URL); err == nil {
+				_ = service.Delete(object)
+			}
+		}()
+		go func() {
+			defer waitGroup.Done()
+			_ = service.Usage()
+			_, _ = service.List("mem:///delete_concurrent")
+		}()
+	}
+	waitGroup.Wait()
+	assert.Equal(t, int64(0), service.Usage())
+}
+
+func TestMemoryService_StorageObjectMetadata(t *testing.T) {
+	service := storage.NewMemoryService()
+	var URL = "mem:///metadata_test/data.txt"
+	var before = time.Now()
+	err := service.Upload(URL, strings.NewReader("metadata"))
+	assert.Nil(t, err)
+	object, err := service.StorageObject(URL)
+	if assert.Nil(t, err) {
+		assert.Equal(t, int64(8), object.Size())
+		assert.False(t, object.ModTime().Before(before))
+		assert.False(t, object.IsFolder())
+	}
+	folder, err := service.StorageObject("mem:///metadata_test")
+	if assert.Nil(t, err) {
+		assert.True(t, folder.IsFolder())
+	}
+}
+
+func TestMemoryService_ExistsPrefix(t *testing.T) {
+	service := storage.NewMemoryService()
+	err := service.Upload("mem:///exists_prefix_test/data/report_2018.csv", strings.NewReader("a,b"))
+	assert.Nil(t, err)
+
+	var useCases = []struct {
+		URL      string
+		expected bool
+	}{
+		{"mem:///exists_prefix_test/data/report", true},
+		{"mem:///exists_prefix_test/data/report_2018.csv", true},
+		{"mem:///exists_prefix_test/da", true},
+		{"mem:///exists_prefix_test/data/summary", false},
+		{"mem:///exists_prefix_test/data/", true},
+		{"mem:///exists_prefix_test/data/report_2018.csv/", false},
+		{"mem:///exists_prefix_missing/", false},
+	}
+	for _, useCase := range useCases {
+		exists, err := service.ExistsPrefix(useCase.URL)
+		assert.Nil(t, err, useCase.URL)
+		assert.Equal(t, useCase.expected, exists, useCase.URL)
+
+		exists, err = storage.ExistsPrefix(service, useCase.URL)
+		if useCase.URL == "mem:///exists_prefix_missing/" {
+			continue
+		}
+		assert.Nil(t, err, useCase.URL)
+		assert.Equal(t, useCase.expected, exists, useCase.URL)
+	}
+}
@@ -2,6 +2,7 @@ package storage
 
 import (
 	"os"
+	"time"
 )
 
 const (
@@ -31,6 +32,12 @@ type Object interface {
 	Unwrap(target interface{}) error
 
 	FileInfo() os.FileInfo
+
+	//Size returns object content size in bytes
+	Size() int64
+
+	//ModTime returns object modification time
+	ModTime() time.Time
 }
 
 //AbstractObject represents abstract storage object
@@ -71,6 +78,30 @@ func (o *AbstractObject) FileInfo() os.FileInfo {
 	return o.fileInfo
 }
 
+//Size returns object content size in bytes
+func (o *AbstractObject) Size() int64 {
+	if o.fileInfo == nil {
+		return 0
+	}
+	return o.fileInfo.Size()
+}
+
+//ModTime returns object modification time
+func (o *AbstractObject) ModTime() time.Time {
+	if o.fileInfo == nil {
+		return time.Time{}
+	}
+	return o.fileInfo.ModTime()
+}
+
+//ContentType returns content type stored with source object or empty string if unknown
+func (o *AbstractObject) ContentType() string {
+	if typer, ok := o.Source.(ContentTyper); ok {
+		return typer.ContentType()
+	}
+	return ""
+}
+
 //NewAbstractStorageObject creates a new abstract storage object
 func NewAbstractStorageObject(url string, source interface{}, fileInfo os.FileInfo) *AbstractObject {
 	var result = &AbstractObject{
@@ -1,14 +1,36 @@
 package storage
 
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/viant/toolbox"
+	"github.com/viant/toolbox/cred"
+)
+
+//Provider represents storage service factory taking credential file
 type Provider func(credentialFile string) (Service, error)
+
+//ConfigProvider represents storage service factory taking structured credential config, config is nil if no credentials were supplied
+type ConfigProvider func(config interface{}) (Service, error)
+
 type StorageProvider struct {
-	Registry map[string]Provider
+	Registry       map[string]Provider
+	ConfigRegistry map[string]ConfigProvider
 }
 
 func (p *StorageProvider) Get(namespace string) func(credentialFile string) (Service, error) {
 	return p.Registry[namespace]
 }
 
+//GetWithConfig returns config based service factory for supplied namespace
+func (p *StorageProvider) GetWithConfig(namespace string) ConfigProvider {
+	return p.ConfigRegistry[namespace]
+}
+
 var storageProvider *StorageProvider
 
 func NewStorageProvider() *StorageProvider {
@@ -16,7 +38,46 @@ func NewStorageProvider() *StorageProvider {
 		return storageProvider
 	}
 	storageProvider = &StorageProvider{
-		Registry: make(map[string]Provider),
+		Registry:       make(map[string]Provider),
+		ConfigRegistry: make(map[string]ConfigProvider),
 	}
 	return storageProvider
 }
+
+//LoadCredentialConfig loads and decodes JSON or YAML (by extension) credential file into a map, relative path is resolved against executable directory
+func LoadCredentialConfig(credentialFile string) (map[string]interface{}, error) {
+	if !strings.HasPrefix(credentialFile, "/") {
+		dir, _ := filepath.Abs(filepath.Dir(os.Args[0]))
+		credentialFile = path.Join(dir, credentialFile)
+	}
+	file, err := os.Open(credentialFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load credential config %v: %v", credentialFile, err)
+	}
+	defer file.Close()
+	var decoderFactory = toolbox.NewJSONDecoderFactory()
+	if ext := path.Ext(credentialFile); ext == ".yaml" || ext == ".yml" {
+		decoderFactory = toolbox.NewYamlDecoderFactory()
+	}
+	var result = make(map[string]interface{})
+	if err = decoderFactory.Create(file).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode credential config %v: %v", credentialFile, err)
+	}
+	return result, nil
+}
+
+//AsCredConfig converts supplied config into credential config, nil config returns nil, map config is decoded with cred.NewConfigFromMap
+func AsCredConfig(config interface{}) (*cred.Config, error) {
+	switch value := config.(type) {
+	case nil:
+		return nil, nil
+	case *cred.Config:
+		return value, nil
+	case cred.Config:
+		return &value, nil
+	}
+	if !toolbox.IsMap(config) {
+		return nil, fmt.Errorf("unsupported credential config type: %T", config)
+	}
+	return cred.NewConfigFromMap(toolbox.AsMap(config))
+}
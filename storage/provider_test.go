@@ -0,0 +1,100 @@
+package storage_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/toolbox/storage"
+)
+
+type configRecorder struct {
+	configs []interface{}
+}
+
+func (r *configRecorder) provider(config interface{}) (storage.Service, error) {
+	r.configs = append(r.configs, config)
+	return storage.NewMemoryService(), nil
+}
+
+func TestNewServiceForURLWithConfig(t *testing.T) {
+	var recorder = &configRecorder{}
+	storage.NewStorageProvider().ConfigRegistry["recorded"] = recorder.provider
+	defer delete(storage.NewStorageProvider().ConfigRegistry, "recorded")
+
+	type credential struct {
+		Key    string
+		Secret string
+	}
+	{ //inline config is passed as is
+		var config = &credential{Key: "k1", Secret: "s1"}
+		service, err := storage.NewServiceForURLWithConfig("recorded://bucket/folder", config)
+		if assert.Nil(t, err) {
+			assert.NotNil(t, service)
+		}
+		if assert.Equal(t, 1, len(recorder.configs)) {
+			assert.True(t, config == recorder.configs[0])
+		}
+	}
+	{ //credential file is loaded and decoded
+		var baseDir = path.Join(os.TempDir(), "service_config_test")
+		defer os.RemoveAll(baseDir)
+		assert.Nil(t, os.MkdirAll(baseDir, 0755))
+		var jsonFile = path.Join(baseDir, "secret.json")
+		assert.Nil(t, ioutil.WriteFile(jsonFile, []byte(`{"Key":"k2","Secret":"s2"}`), 0644))
+		var yamlFile = path.Join(baseDir, "secret.yaml")
+		assert.Nil(t, ioutil.WriteFile(yamlFile, []byte("Key: k3\nSecret: s3\n"), 0644))
+
+		_, err := storage.NewServiceForURL("recorded://bucket/folder", jsonFile)
+		assert.Nil(t, err)
+		_, err = storage.NewServiceForURL("recorded://bucket/folder", yamlFile)
+		assert.Nil(t, err)
+		_, err = storage.NewServiceForURL("recorded://bucket/folder", "")
+		assert.Nil(t, err)
+		if assert.Equal(t, 4, len(recorder.configs)) {
+			assert.EqualValues(t, map[string]interface{}{"Key": "k2", "Secret": "s2"}, recorder.configs[1])
+			assert.EqualValues(t, map[string]interface{}{"Key": "k3", "Secret": "s3"}, recorder.configs[2])
+			assert.Nil(t, recorder.configs[3])
+		}
+		_, err = storage.NewServiceForURL("recorded://bucket/folder", path.Join(baseDir, "missing.json"))
+		assert.NotNil(t, err)
+	}
+	{ //credential file providers are still supported
+		var credentialFiles = make([]string, 0)
+		storage.NewStorageProvider().Registry["legacy"] = func(credentialFile string) (storage.Service, error) {
+			credentialFiles = append(credentialFiles, credentialFile)
+			return storage.NewMemoryService(), nil
+		}
+		defer delete(storage.NewStorageProvider().Registry, "legacy")
+		_, err := storage.NewServiceForURL("legacy://host/path", "/etc/secret.json")
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"/etc/secret.json"}, credentialFiles)
+		_, err = storage.NewServiceForURLWithConfig("legacy://host/path", map[string]interface{}{"Key": "k"})
+		assert.NotNil(t, err)
+
+		//credential file provider takes precedence over config provider
+		storage.NewStorageProvider().ConfigRegistry["legacy"] = recorder.provider
+		defer delete(storage.NewStorageProvider().ConfigRegistry, "legacy")
+		var configs = len(recorder.configs)
+		_, err = storage.NewServiceForURL("legacy://host/path", "secret.json")
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"/etc/secret.json", "secret.json"}, credentialFiles)
+		assert.Equal(t, configs, len(recorder.configs))
+	}
+	{ //credential file is ignored by schemes without credentials
+		for _, URL := range []string{"file:///tmp", "mem:///provider_test"} {
+			service, err := storage.NewServiceForURL(URL, "/missing/secret.json")
+			assert.Nil(t, err, URL)
+			assert.NotNil(t, service, URL)
+		}
+	}
+	{
+		_, err := storage.NewServiceForURLWithConfig("unknown://host/path", nil)
+		assert.NotNil(t, err)
+		service, err := storage.NewServiceForURLWithConfig("file:///tmp", nil)
+		assert.Nil(t, err)
+		assert.NotNil(t, service)
+	}
+}
@@ -0,0 +1,148 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+)
+
+//ReadOnlyError represents an error returned by read only service mutating operations, use IsReadOnlyError to check it
+type ReadOnlyError struct {
+	Operation string
+	Target    string
+}
+
+func (e *ReadOnlyError) Error() string {
+	return fmt.Sprintf("failed to %v %v: storage is read only", e.Operation, e.Target)
+}
+
+//IsReadOnlyError returns true if supplied error was returned by read only service mutating operation
+func IsReadOnlyError(err error) bool {
+	_, ok := err.(*ReadOnlyError)
+	return ok
+}
+
+//readOnlyStorageService represents a storage service decorator that forwards read operations and rejects mutating ones,
+//delegate is not embedded so that any method added to Service has to be explicitly classified here
+type readOnlyStorageService struct {
+	delegate Service
+}
+
+func readOnlyError(operation, URL string) error {
+	return &ReadOnlyError{Operation: operation, Target: URL}
+}
+
+//List returns a list of object for supplied url
+func (s *readOnlyStorageService) List(URL string) ([]Object, error) {
+	return s.delegate.List(URL)
+}
+
+//ListMatch returns objects for supplied url whose base name matches shell pattern
+func (s *readOnlyStorageService) ListMatch(URL, pattern string) ([]Object, error) {
+	return s.delegate.ListMatch(URL, pattern)
+}
+
+//ListRecursive returns all objects under supplied url
+func (s *readOnlyStorageService) ListRecursive(URL string) ([]Object, error) {
+	return s.delegate.ListRecursive(URL)
+}
+
+//Walk calls visitor with each object under supplied url
+func (s *readOnlyStorageService) Walk(URL string, visitor WalkVisitor) error {
+	return s.delegate.Walk(URL, visitor)
+}
+
+//Exists returns true if resource exists
+func (s *readOnlyStorageService) Exists(URL string) (bool, error) {
+	return s.delegate.Exists(URL)
+}
+
+//ExistsPrefix returns true if at least one object URL starts with supplied URL
+func (s *readOnlyStorageService) ExistsPrefix(URL string) (bool, error) {
+	return s.delegate.ExistsPrefix(URL)
+}
+
+//StorageObject returns a Object for supplied url
+func (s *readOnlyStorageService) StorageObject(URL string) (Object, error) {
+	return s.delegate.StorageObject(URL)
+}
+
+//Download returns reader for downloaded storage object
+func (s *readOnlyStorageService) Download(object Object) (io.Reader, error) {
+	return s.delegate.Download(object)
+}
+
+//DownloadRange returns reader for downloaded storage object content window
+func (s *readOnlyStorageService) DownloadRange(object Object, offset, length int64) (io.Reader, error) {
+	return s.delegate.DownloadRange(object, offset, length)
+}
+
+//DownloadWithContext returns reader for downloaded storage object
+func (s *readOnlyStorageService) DownloadWithContext(ctx context.Context, object Object) (io.Reader, error) {
+	return s.delegate.DownloadWithContext(ctx, object)
+}
+
+//ChecksumMD5 returns hex encoded MD5 digest of storage object content
+func (s *readOnlyStorageService) ChecksumMD5(object Object) (string, error) {
+	return s.delegate.ChecksumMD5(object)
+}
+
+//ChecksumSHA256 returns hex encoded SHA-256 digest of storage object content
+func (s *readOnlyStorageService) ChecksumSHA256(object Object) (string, error) {
+	return s.delegate.ChecksumSHA256(object)
+}
+
+//CreateFolder returns ReadOnlyError
+func (s *readOnlyStorageService) CreateFolder(URL string) error {
+	return readOnlyError("create folder", URL)
+}
+
+//Upload returns ReadOnlyError
+func (s *readOnlyStorageService) Upload(URL string, reader io.Reader) error {
+	return readOnlyError("upload", URL)
+}
+
+//UploadWithOptions returns ReadOnlyError
+func (s *readOnlyStorageService) UploadWithOptions(URL string, reader io.Reader, options UploadOptions) error {
+	return readOnlyError("upload", URL)
+}
+
+//UploadWithContext returns ReadOnlyError
+func (s *readOnlyStorageService) UploadWithContext(ctx context.Context, URL string, reader io.Reader) error {
+	return readOnlyError("upload", URL)
+}
+
+//Delete returns ReadOnlyError
+func (s *readOnlyStorageService) Delete(object Object) error {
+	return readOnlyError("delete", object.URL())
+}
+
+//DeleteAll returns ReadOnlyError
+func (s *readOnlyStorageService) DeleteAll(objects []Object) error {
+	return readOnlyError("delete", fmt.Sprintf("%v object(s)", len(objects)))
+}
+
+//Copy returns ReadOnlyError
+func (s *readOnlyStorageService) Copy(sourceURL, destinationURL string) error {
+	return readOnlyError("copy to", destinationURL)
+}
+
+//Move returns ReadOnlyError
+func (s *readOnlyStorageService) Move(sourceURL, destinationURL string) error {
+	return readOnlyError("move", sourceURL)
+}
+
+//Register returns ReadOnlyError
+func (s *readOnlyStorageService) Register(schema string, service Service) error {
+	return readOnlyError("register", schema)
+}
+
+//Close closes delegate service
+func (s *readOnlyStorageService) Close() error {
+	return s.delegate.Close()
+}
+
+//NewReadOnlyService creates a new storage service that forwards read operations to delegate and rejects all mutating operations with ReadOnlyError
+func NewReadOnlyService(delegate Service) Service {
+	return &readOnlyStorageService{delegate: delegate}
+}
@@ -0,0 +1,52 @@
+package storage_test
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/toolbox/storage"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func TestNewReadOnlyService(t *testing.T) {
+	delegate := storage.NewMemoryServiceWithLimit(0)
+	assert.Nil(t, delegate.Upload("mem:///read_only_test/file.txt", strings.NewReader("abc")))
+	service := storage.NewReadOnlyService(delegate)
+
+	objects, err := service.List("mem:///read_only_test")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(objects))
+	exists, err := service.Exists("mem:///read_only_test/file.txt")
+	assert.Nil(t, err)
+	assert.True(t, exists)
+	object, err := service.StorageObject("mem:///read_only_test/file.txt")
+	if !assert.Nil(t, err) {
+		return
+	}
+	reader, err := service.Download(object)
+	if assert.Nil(t, err) {
+		content, err := ioutil.ReadAll(reader)
+		assert.Nil(t, err)
+		assert.Equal(t, "abc", string(content))
+	}
+
+	for _, err := range []error{
+		service.Upload("mem:///read_only_test/new.txt", strings.NewReader("xyz")),
+		service.UploadWithOptions("mem:///read_only_test/new.txt", strings.NewReader("xyz"), storage.UploadOptions{}),
+		service.CreateFolder("mem:///read_only_test/sub"),
+		service.Delete(object),
+		service.DeleteAll([]storage.Object{object}),
+		service.Copy("mem:///read_only_test/file.txt", "mem:///read_only_test/copy.txt"),
+		service.Move("mem:///read_only_test/file.txt", "mem:///read_only_test/moved.txt"),
+		service.Register("mem", delegate),
+	} {
+		if assert.NotNil(t, err) {
+			assert.True(t, storage.IsReadOnlyError(err), err.Error())
+		}
+	}
+	assert.False(t, storage.IsReadOnlyError(errors.New("storage is read only")))
+	objects, err = delegate.ListRecursive("mem:///read_only_test")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(objects))
+}
@@ -0,0 +1,169 @@
+package storage
+
+import (
+	"io"
+	"math/rand"
+	"net"
+	"net/url"
+	"os"
+	"strings"
+	"syscall"
+	"time"
+)
+
+//RetryPredicate returns true if operation that failed with passed in error should be retried
+type RetryPredicate func(err error) bool
+
+//retryableErrorFragments represents transient error messages of errors that do not carry type information (i.e. http status based or %v wrapped errors)
+var retryableErrorFragments = []string{
+	"connection reset",
+	"connection refused",
+	"too many requests",
+	"internal server error",
+	"bad gateway",
+	"service unavailable",
+	"gateway timeout",
+	"status code: 5",
+}
+
+//rootError returns error wrapped by url, network operation and syscall errors
+func rootError(err error) error {
+	for {
+		switch actual := err.(type) {
+		case *url.Error:
+			err = actual.Err
+		case *net.OpError:
+			err = actual.Err
+		case *os.SyscallError:
+			err = actual.Err
+		default:
+			return err
+		}
+	}
+}
+
+//IsRetryableError returns true for timeouts, connection reset/refused, temporary DNS failures and 5xx style errors,
+//other network errors (i.e. DNS no such host) are not retried
+func IsRetryableError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if err == io.ErrUnexpectedEOF {
+		return true
+	}
+	switch actual := rootError(err).(type) {
+	case syscall.Errno:
+		return actual == syscall.ECONNRESET || actual == syscall.ECONNREFUSED || actual == syscall.EPIPE
+	case *net.DNSError:
+		return actual.IsTimeout || actual.IsTemporary
+	case net.Error:
+		return actual.Timeout()
+	}
+	var message = strings.ToLower(err.Error())
+	for _, fragment := range retryableErrorFragments {
+		if strings.Contains(message, fragment) {
+			return true
+		}
+	}
+	return false
+}
+
+//retryStorageService represents a storage service decorator that retries List, Download, Upload and Delete on retryable errors
+type retryStorageService struct {
+	Service
+	maxRetries int
+	baseDelay  time.Duration
+	retryable  RetryPredicate
+}
+
+//delay returns exponential backoff delay with up to 50% jitter for passed in attempt
+func (s *retryStorageService) delay(attempt int) time.Duration {
+	var result = s.baseDelay << uint(attempt)
+	if result <= 0 {
+		return 0
+	}
+	return result + time.Duration(rand.Int63n(int64(result)/2+1))
+}
+
+func (s *retryStorageService) retry(operation func() error) error {
+	var err error
+	for attempt := 0; ; attempt++ {
+		if err = operation(); err == nil || attempt >= s.maxRetries || !s.retryable(err) {
+			return err
+		}
+		time.Sleep(s.delay(attempt))
+	}
+}
+
+//List returns a list of object for supplied url
+func (s *retryStorageService) List(URL string) ([]Object, error) {
+	var result []Object
+	err := s.retry(func() (err error) {
+		result, err = s.Service.List(URL)
+		return err
+	})
+	return result, err
+}
+
+//ListMatch returns objects for supplied url whose base name matches shell pattern, listing is retried with List
+func (s *retryStorageService) ListMatch(URL, pattern string) ([]Object, error) {
+	return ListMatch(s, URL, pattern)
+}
+
+//Download returns reader for downloaded storage object
+func (s *retryStorageService) Download(object Object) (io.Reader, error) {
+	var result io.Reader
+	err := s.retry(func() (err error) {
+		result, err = s.Service.Download(object)
+		return err
+	})
+	return result, err
+}
+
+//Upload uploads provided reader content for supplied url, upload is retried only if reader implements io.Seeker,
+//it is rewound to its initial position before each retry, other readers are uploaded once as their content can not be replayed
+func (s *retryStorageService) Upload(URL string, reader io.Reader) error {
+	seeker, ok := reader.(io.Seeker)
+	if !ok {
+		return s.Service.Upload(URL, reader)
+	}
+	offset, err := seeker.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return s.Service.Upload(URL, reader)
+	}
+	var attempt = 0
+	return s.retry(func() error {
+		if attempt++; attempt > 1 {
+			if _, err := seeker.Seek(offset, io.SeekStart); err != nil {
+				return err
+			}
+		}
+		return s.Service.Upload(URL, reader)
+	})
+}
+
+//Delete removes passed in storage object
+func (s *retryStorageService) Delete(object Object) error {
+	return s.retry(func() error {
+		return s.Service.Delete(object)
+	})
+}
+
+//NewRetryService creates a new storage service that retries failed List, Download, Upload and Delete operations up to maxRetries times,
+//with exponential backoff starting at baseDelay and jitter. Only errors matching IsRetryableError are retried.
+func NewRetryService(delegate Service, maxRetries int, baseDelay time.Duration) Service {
+	return NewRetryServiceWithPredicate(delegate, maxRetries, baseDelay, IsRetryableError)
+}
+
+//NewRetryServiceWithPredicate creates a new retrying storage service with custom retryable error predicate
+func NewRetryServiceWithPredicate(delegate Service, maxRetries int, baseDelay time.Duration, retryable RetryPredicate) Service {
+	if retryable == nil {
+		retryable = IsRetryableError
+	}
+	return &retryStorageService{
+		Service:    delegate,
+		maxRetries: maxRetries,
+		baseDelay:  baseDelay,
+		retryable:  retryable,
+	}
+}
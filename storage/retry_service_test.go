@@ -0,0 +1,141 @@
+package storage_test
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/toolbox"
+	"github.com/viant/toolbox/storage"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+//flakyService fails first failures calls of List, Download, Upload and Delete with err
+type flakyService struct {
+	storage.Service
+	failures int
+	err      error
+	calls    int
+}
+
+func (s *flakyService) fail() error {
+	s.calls++
+	if s.calls <= s.failures {
+		return s.err
+	}
+	return nil
+}
+
+func (s *flakyService) List(URL string) ([]storage.Object, error) {
+	if err := s.fail(); err != nil {
+		return nil, err
+	}
+	return s.Service.List(URL)
+}
+
+func (s *flakyService) Download(object storage.Object) (io.Reader, error) {
+	if err := s.fail(); err != nil {
+		return nil, err
+	}
+	return s.Service.Download(object)
+}
+
+func (s *flakyService) Upload(URL string, reader io.Reader) error {
+	if err := s.fail(); err != nil {
+		ioutil.ReadAll(reader)
+		return err
+	}
+	return s.Service.Upload(URL, reader)
+}
+
+func TestNewRetryService(t *testing.T) {
+	var baseDir = path.Join(os.TempDir(), "storage_retry_test")
+	defer os.RemoveAll(baseDir)
+	var URL = toolbox.FileSchema + path.Join(baseDir, "data.txt")
+	var transientError = errors.New("503 service unavailable")
+
+	{ //upload succeeds after transient failures with buffered content
+		delegate := &flakyService{Service: storage.NewFileStorage(), failures: 2, err: transientError}
+		service := storage.NewRetryService(delegate, 3, time.Millisecond)
+		err := service.Upload(URL, strings.NewReader("abc"))
+		assert.Nil(t, err)
+		assert.Equal(t, 3, delegate.calls)
+		content, err := ioutil.ReadFile(path.Join(baseDir, "data.txt"))
+		assert.Nil(t, err)
+		assert.Equal(t, "abc", string(content))
+	}
+	{ //upload of reader that can not be rewound is not retried
+		delegate := &flakyService{Service: storage.NewFileStorage(), failures: 1, err: transientError}
+		service := storage.NewRetryService(delegate, 3, time.Millisecond)
+		err := service.Upload(URL, io.MultiReader(strings.NewReader("xyz")))
+		assert.Equal(t, transientError, err)
+		assert.Equal(t, 1, delegate.calls)
+	}
+	{ //list and download succeed after transient failures
+		delegate := &flakyService{Service: storage.NewFileStorage(), failures: 1, err: transientError}
+		service := storage.NewRetryService(delegate, 1, time.Millisecond)
+		objects, err := service.List(URL)
+		if assert.Nil(t, err) && assert.Equal(t, 1, len(objects)) {
+			delegate.calls, delegate.failures = 0, 1
+			reader, err := service.Download(objects[0])
+			if assert.Nil(t, err) {
+				content, _ := ioutil.ReadAll(reader)
+				assert.Equal(t, "abc", string(content))
+			}
+		}
+	}
+	{ //retries are exhausted
+		delegate := &flakyService{Service: storage.NewFileStorage(), failures: 5, err: transientError}
+		service := storage.NewRetryService(delegate, 2, time.Millisecond)
+		_, err := service.List(URL)
+		assert.Equal(t, transientError, err)
+		assert.Equal(t, 3, delegate.calls)
+	}
+	{ //non retryable error returns immediately
+		delegate := &flakyService{Service: storage.NewFileStorage(), failures: 5, err: errors.New("access denied")}
+		service := storage.NewRetryService(delegate, 3, time.Millisecond)
+		_, err := service.List(URL)
+		assert.NotNil(t, err)
+		assert.Equal(t, 1, delegate.calls)
+	}
+	{ //custom predicate
+		delegate := &flakyService{Service: storage.NewFileStorage(), failures: 1, err: errors.New("access denied")}
+		service := storage.NewRetryServiceWithPredicate(delegate, 3, time.Millisecond, func(err error) bool {
+			return true
+		})
+		_, err := service.List(URL)
+		assert.Nil(t, err)
+		assert.Equal(t, 2, delegate.calls)
+	}
+}
+
+func TestIsRetryableError(t *testing.T) {
+	assert.True(t, storage.IsRetryableError(errors.New("read tcp: connection reset by peer")))
+	assert.True(t, storage.IsRetryableError(errors.New("Invalid response code: 502 Bad Gateway")))
+	assert.True(t, storage.IsRetryableError(io.ErrUnexpectedEOF))
+	assert.False(t, storage.IsRetryableError(errors.New("No such file or directory")))
+	assert.False(t, storage.IsRetryableError(errors.New("invalid timeout value")))
+	assert.False(t, storage.IsRetryableError(nil))
+
+	assert.True(t, storage.IsRetryableError(&net.OpError{Op: "read", Net: "tcp", Err: os.NewSyscallError("read", syscall.ECONNRESET)}))
+	assert.True(t, storage.IsRetryableError(&net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}))
+	assert.True(t, storage.IsRetryableError(&net.OpError{Op: "read", Net: "tcp", Err: timeoutError{}}))
+	assert.False(t, storage.IsRetryableError(&net.OpError{Op: "read", Net: "tcp", Err: errors.New("use of closed network connection")}))
+	assert.True(t, storage.IsRetryableError(&net.DNSError{Err: "i/o timeout", Name: "storage.example.com", IsTimeout: true}))
+	assert.False(t, storage.IsRetryableError(&net.OpError{Op: "dial", Net: "tcp", Err: &net.DNSError{Err: "no such host", Name: "missing.example.com"}}))
+	assert.True(t, storage.IsRetryableError(&url.Error{Op: "Get", URL: "http://storage.example.com", Err: &net.OpError{Op: "read", Net: "tcp", Err: timeoutError{}}}))
+}
+
+//timeoutError represents network timeout error
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
@@ -0,0 +1,245 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/viant/toolbox"
+	"io/ioutil"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+type schemaProvider struct {
+	service Service
+	mutex   *sync.RWMutex
+	schemas map[string]map[string]interface{}
+}
+
+func (p *schemaProvider) schema(URL string) (map[string]interface{}, error) {
+	p.mutex.RLock()
+	result, ok := p.schemas[URL]
+	p.mutex.RUnlock()
+	if ok {
+		return result, nil
+	}
+	object, err := p.service.StorageObject(URL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load schema %v: %v", URL, err)
+	}
+	reader, err := p.service.Download(object)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load schema %v: %v", URL, err)
+	}
+	content, err := ioutil.ReadAll(reader)
+	closeReader(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load schema %v: %v", URL, err)
+	}
+	result = make(map[string]interface{})
+	if err = json.Unmarshal(content, &result); err != nil {
+		return nil, fmt.Errorf("failed to decode schema %v: %v", URL, err)
+	}
+	p.mutex.Lock()
+	p.schemas[URL] = result
+	p.mutex.Unlock()
+	return result, nil
+}
+
+func (p *schemaProvider) hasType(value interface{}, typeName string) bool {
+	switch typeName {
+	case "null":
+		return value == nil
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "number":
+		_, ok := value.(float64)
+		return ok
+	case "integer":
+		number, ok := value.(float64)
+		return ok && number == math.Trunc(number)
+	case "object":
+		_, ok := value.(map[string]interface{})
+		return ok
+	case "array":
+		_, ok := value.([]interface{})
+		return ok
+	}
+	return false
+}
+
+//validate validates document against supported JSON schema subset: type, enum, const, required, properties, additionalProperties, items,
+//minItems, maxItems, minLength, maxLength, pattern, minimum, maximum
+func (p *schemaProvider) validate(path string, schema map[string]interface{}, document interface{}, errors *[]string) {
+	var report = func(format string, arguments ...interface{}) {
+		var location = path
+		if location == "" {
+			location = "$"
+		}
+		*errors = append(*errors, location+": "+fmt.Sprintf(format, arguments...))
+	}
+	if typeValue, ok := schema["type"]; ok {
+		var types = make([]string, 0)
+		if typeName, ok := typeValue.(string); ok {
+			types = append(types, typeName)
+		} else {
+			for _, item := range toolbox.AsSlice(typeValue) {
+				types = append(types, toolbox.AsString(item))
+			}
+		}
+		var matched = false
+		for _, typeName := range types {
+			if p.hasType(document, typeName) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			report("expected %v but had %T", strings.Join(types, " or "), document)
+			return
+		}
+	}
+	if enum, ok := schema["enum"].([]interface{}); ok {
+		var matched = false
+		for _, candidate := range enum {
+			if reflect.DeepEqual(candidate, document) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			report("value %v is not one of %v", document, enum)
+		}
+	}
+	if constValue, ok := schema["const"]; ok && !reflect.DeepEqual(constValue, document) {
+		report("expected %v but had %v", constValue, document)
+	}
+	switch value := document.(type) {
+	case map[string]interface{}:
+		for _, item := range toolbox.AsSlice(schema["required"]) {
+			if _, has := value[toolbox.AsString(item)]; !has {
+				report("missing required property %v", item)
+			}
+		}
+		properties, _ := schema["properties"].(map[string]interface{})
+		var keys = toolbox.MapKeysToStringSlice(value)
+		sort.Strings(keys)
+		for _, key := range keys {
+			var propertyPath = key
+			if path != "" {
+				propertyPath = path + "." + key
+			}
+			if propertySchema, ok := properties[key].(map[string]interface{}); ok {
+				p.validate(propertyPath, propertySchema, value[key], errors)
+				continue
+			}
+			switch additional := schema["additionalProperties"].(type) {
+			case bool:
+				if !additional {
+					report("additional property %v is not allowed", key)
+				}
+			case map[string]interface{}:
+				p.validate(propertyPath, additional, value[key], errors)
+			}
+		}
+	case []interface{}:
+		if minItems, ok := schema["minItems"]; ok && len(value) < toolbox.AsInt(minItems) {
+			report("expected at least %v items but had %v", minItems, len(value))
+		}
+		if maxItems, ok := schema["maxItems"]; ok && len(value) > toolbox.AsInt(maxItems) {
+			report("expected at most %v items but had %v", maxItems, len(value))
+		}
+		if itemSchema, ok := schema["items"].(map[string]interface{}); ok {
+			for i, item := range value {
+				p.validate(fmt.Sprintf("%v[%v]", path, i), itemSchema, item, errors)
+			}
+		}
+	case string:
+		var length = len([]rune(value))
+		if minLength, ok := schema["minLength"]; ok && length < toolbox.AsInt(minLength) {
+			report("expected at least %v characters but had %v", minLength, length)
+		}
+		if maxLength, ok := schema["maxLength"]; ok && length > toolbox.AsInt(maxLength) {
+			report("expected at most %v characters but had %v", maxLength, length)
+		}
+		if pattern, ok := schema["pattern"].(string); ok {
+			expression, err := regexp.Compile(pattern)
+			if err != nil {
+				report("invalid pattern %v: %v", pattern, err)
+			} else if !expression.MatchString(value) {
+				report("value %v does not match pattern %v", value, pattern)
+			}
+		}
+	case float64:
+		if minimum, ok := schema["minimum"]; ok && value < toolbox.AsFloat(minimum) {
+			report("value %v is less than minimum %v", value, minimum)
+		}
+		if maximum, ok := schema["maximum"]; ok && value > toolbox.AsFloat(maximum) {
+			report("value %v is greater than maximum %v", value, maximum)
+		}
+	}
+}
+
+//normalize converts document into generic JSON representation
+func (p *schemaProvider) normalize(document interface{}) (interface{}, error) {
+	var content []byte
+	switch value := document.(type) {
+	case string:
+		content = []byte(value)
+	case []byte:
+		content = value
+	default:
+		var err error
+		if content, err = json.Marshal(document); err != nil {
+			return nil, fmt.Errorf("failed to encode document: %v", err)
+		}
+	}
+	var result interface{}
+	if err := json.Unmarshal(content, &result); err != nil {
+		return nil, fmt.Errorf("failed to decode document: %v", err)
+	}
+	return result, nil
+}
+
+func (p *schemaProvider) Get(context toolbox.Context, arguments ...interface{}) (interface{}, error) {
+	if len(arguments) != 3 {
+		return nil, fmt.Errorf("expected 3 arguments (operation, schemaURL, document) but had: %v", len(arguments))
+	}
+	var operation = toolbox.AsString(arguments[0])
+	if operation != "validate" && operation != "errors" {
+		return nil, fmt.Errorf("unsupported schema operation: %v", operation)
+	}
+	schema, err := p.schema(toolbox.AsString(arguments[1]))
+	if err != nil {
+		return nil, err
+	}
+	document, err := p.normalize(arguments[2])
+	if err != nil {
+		return nil, err
+	}
+	var errors = make([]string, 0)
+	p.validate("", schema, document, &errors)
+	if operation == "errors" {
+		return errors, nil
+	}
+	return len(errors) == 0, nil
+}
+
+//NewSchemaProvider returns a provider that validates a document against JSON schema loaded (and cached) with passed in storage service,
+//Get(context, "validate", schemaURL, document) returns true if document conforms, "errors" operation returns a list of validation errors.
+//Schema load failures are returned as error. Supported keywords: type, enum, const, required, properties, additionalProperties, items,
+//minItems, maxItems, minLength, maxLength, pattern, minimum, maximum.
+func NewSchemaProvider(service Service) toolbox.ValueProvider {
+	return &schemaProvider{
+		service: service,
+		mutex:   &sync.RWMutex{},
+		schemas: make(map[string]map[string]interface{}),
+	}
+}
@@ -0,0 +1,76 @@
+package storage_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/toolbox/storage"
+	"strings"
+	"testing"
+)
+
+func TestNewSchemaProvider(t *testing.T) {
+	service := storage.NewMemoryService()
+	const schemaURL = "mem:///schema/user.json"
+	err := service.Upload(schemaURL, strings.NewReader(`{
+		"type": "object",
+		"required": ["id", "name"],
+		"additionalProperties": false,
+		"properties": {
+			"id": {"type": "integer", "minimum": 1},
+			"name": {"type": "string", "minLength": 2, "pattern": "^[a-z]+$"},
+			"role": {"enum": ["admin", "user"]},
+			"tags": {"type": "array", "maxItems": 2, "items": {"type": "string"}}
+		}
+	}`))
+	assert.Nil(t, err)
+	provider := storage.NewSchemaProvider(service)
+	{
+		value, err := provider.Get(nil, "validate", schemaURL, map[string]interface{}{"id": 1, "name": "bob", "role": "admin", "tags": []string{"a"}})
+		assert.Nil(t, err)
+		assert.Equal(t, true, value)
+	}
+	{
+		value, err := provider.Get(nil, "validate", schemaURL, `{"id": 1.5, "name": "bob"}`)
+		assert.Nil(t, err)
+		assert.Equal(t, false, value)
+	}
+	{
+		value, err := provider.Get(nil, "errors", schemaURL, `{"id": 0, "name": "B", "role": "guest", "tags": ["a", 2, "c"], "extra": 1}`)
+		assert.Nil(t, err)
+		assert.EqualValues(t, []string{
+			"$: additional property extra is not allowed",
+			"id: value 0 is less than minimum 1",
+			"name: expected at least 2 characters but had 1",
+			"name: value B does not match pattern ^[a-z]+$",
+			"role: value guest is not one of [admin user]",
+			"tags: expected at most 2 items but had 3",
+			"tags[1]: expected string but had float64",
+		}, value)
+	}
+	{
+		value, err := provider.Get(nil, "errors", schemaURL, `{}`)
+		assert.Nil(t, err)
+		assert.EqualValues(t, []string{"$: missing required property id", "$: missing required property name"}, value)
+	}
+	{
+		//schema is cached
+		object, err := service.StorageObject(schemaURL)
+		assert.Nil(t, err)
+		err = service.Delete(object)
+		assert.Nil(t, err)
+		value, err := provider.Get(nil, "validate", schemaURL, `{"id": 2, "name": "abc"}`)
+		assert.Nil(t, err)
+		assert.Equal(t, true, value)
+	}
+	{
+		_, err := provider.Get(nil, "validate", "mem:///schema/missing.json", `{}`)
+		assert.NotNil(t, err)
+	}
+	{
+		_, err := provider.Get(nil, "validate", schemaURL, `{`)
+		assert.NotNil(t, err)
+	}
+	{
+		_, err := provider.Get(nil, "check", schemaURL, `{}`)
+		assert.NotNil(t, err)
+	}
+}
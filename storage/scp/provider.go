@@ -13,6 +13,7 @@ const ProviderScheme = "scp"
 
 func init() {
 	storage.NewStorageProvider().Registry[ProviderScheme] = serviceProvider
+	storage.NewStorageProvider().ConfigRegistry[ProviderScheme] = serviceConfigProvider
 }
 
 func serviceProvider(credentialFile string) (storage.Service, error) {
@@ -31,3 +32,15 @@ func serviceProvider(credentialFile string) (storage.Service, error) {
 	}
 	return NewService(config), nil
 }
+
+//serviceConfigProvider creates a service for cred.Config or map config, nil config uses empty credential config
+func serviceConfigProvider(config interface{}) (storage.Service, error) {
+	credential, err := storage.AsCredConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	if credential == nil {
+		credential = &cred.Config{}
+	}
+	return NewService(credential), nil
+}
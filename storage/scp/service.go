@@ -2,6 +2,7 @@ package scp
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"github.com/lunixbochs/vtclean"
@@ -120,6 +121,11 @@ func (s *service) List(URL string) ([]storage.Object, error) {
 	return objects, nil
 }
 
+//ExistsPrefix returns true if at least one object URL starts with supplied URL
+func (s *service) ExistsPrefix(URL string) (bool, error) {
+	return storage.ExistsPrefix(s, URL)
+}
+
 func (s *service) Exists(URL string) (bool, error) {
 	parsedURL, err := url.Parse(URL)
 	if err != nil {
@@ -184,6 +190,29 @@ func (s *service) Download(object storage.Object) (io.Reader, error) {
 	return bytes.NewReader(content), nil
 }
 
+//CreateFolder creates remote directory with all missing parent directories for supplied URL
+func (s *service) CreateFolder(URL string) error {
+	parsedURL, err := url.Parse(URL)
+	if err != nil {
+		return err
+	}
+	if parsedURL.Host == "127.0.0.1" || parsedURL.Host == "127.0.0.1:22" {
+		return s.fileService.CreateFolder(toolbox.FileSchema + parsedURL.Path)
+	}
+	port := toolbox.AsInt(parsedURL.Port())
+	if port == 0 {
+		port = defaultSSHPort
+	}
+	service, err := ssh.NewService(parsedURL.Hostname(), toolbox.AsInt(port), s.config)
+	if err != nil {
+		return err
+	}
+	if err = service.Run("mkdir -p " + parsedURL.Path); err != nil {
+		return fmt.Errorf("failed to create folder: %v %v", URL, err)
+	}
+	return nil
+}
+
 //Upload uploads provided reader content for supplied URL.
 func (s *service) Upload(URL string, reader io.Reader) error {
 	parsedURL, err := url.Parse(URL)
@@ -235,6 +264,67 @@ func (s *service) Upload(URL string, reader io.Reader) error {
 	return err
 }
 
+//DownloadWithContext returns reader for downloaded storage object, transfer is aborted once context is done
+func (s *service) DownloadWithContext(ctx context.Context, object storage.Object) (io.Reader, error) {
+	return storage.DownloadWithContext(ctx, s, object)
+}
+
+//UploadWithOptions uploads provided reader content for supplied url, only compress option is used
+func (s *service) UploadWithOptions(URL string, reader io.Reader, options storage.UploadOptions) error {
+	URL, reader = storage.CompressedUpload(URL, reader, options)
+	return s.Upload(URL, reader)
+}
+
+//UploadWithContext uploads provided reader content for supplied url, transfer is aborted once context is done
+func (s *service) UploadWithContext(ctx context.Context, URL string, reader io.Reader) error {
+	return storage.UploadWithContext(ctx, s, URL, reader)
+}
+
+//ChecksumMD5 returns MD5 hex digest of storage object content
+func (s *service) ChecksumMD5(object storage.Object) (string, error) {
+	return storage.ChecksumMD5(s, object)
+}
+
+//ChecksumSHA256 returns SHA-256 hex digest of storage object content
+func (s *service) ChecksumSHA256(object storage.Object) (string, error) {
+	return storage.ChecksumSHA256(s, object)
+}
+
+//DownloadRange returns reader for downloaded storage object content window
+func (s *service) DownloadRange(object storage.Object, offset, length int64) (io.Reader, error) {
+	return storage.DownloadRange(s, object, offset, length)
+}
+
+//ListMatch returns objects for supplied url whose base name matches shell pattern
+func (s *service) ListMatch(URL, pattern string) ([]storage.Object, error) {
+	return storage.ListMatch(s, URL, pattern)
+}
+
+//ListRecursive returns all objects under supplied url
+func (s *service) ListRecursive(URL string) ([]storage.Object, error) {
+	return storage.ListRecursive(s, URL)
+}
+
+//Walk calls visitor with each object under supplied url
+func (s *service) Walk(URL string, visitor storage.WalkVisitor) error {
+	return storage.Walk(s, URL, visitor)
+}
+
+//DeleteAll removes passed in storage objects
+func (s *service) DeleteAll(objects []storage.Object) error {
+	return storage.DeleteAll(s, objects)
+}
+
+//Copy copies object from source URL to destination URL
+func (s *service) Copy(sourceURL, destinationURL string) error {
+	return storage.CopyObject(s, sourceURL, s, destinationURL)
+}
+
+//Move moves object from source URL to destination URL
+func (s *service) Move(sourceURL, destinationURL string) error {
+	return storage.MoveObject(s, sourceURL, s, destinationURL)
+}
+
 func (s *service) Register(schema string, service storage.Service) error {
 	return errors.New("unsupported")
 }
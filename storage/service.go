@@ -1,31 +1,87 @@
 package storage
 
 import (
+	"context"
 	"fmt"
+	"github.com/viant/toolbox"
 	"io"
 	"net/url"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
 )
 
+//UploadOptions represents upload content type, cache control and custom metadata, Compress uploads gzip compressed content with .gz extension
+type UploadOptions struct {
+	ContentType  string
+	CacheControl string
+	Metadata     map[string]string
+	Compress     bool
+}
+
 //Service represents abstract way to accessing local or remote storage
 type Service interface {
 	//List returns a list of object for supplied url
 	List(URL string) ([]Object, error)
 
+	//ListMatch returns objects for supplied url whose base name matches shell pattern (see filepath.Match), invalid pattern is an error
+	ListMatch(URL, pattern string) ([]Object, error)
+
+	//ListRecursive returns all objects under supplied url including nested folders and their content
+	ListRecursive(URL string) ([]Object, error)
+
+	//Walk calls visitor with each object under supplied url including nested folders and their content, walk stops without error once visitor returns StopWalkError
+	Walk(URL string, visitor WalkVisitor) error
+
 	//Exists returns true if resource exists
 	Exists(URL string) (bool, error)
 
+	//ExistsPrefix returns true if at least one object URL starts with supplied URL, URL with trailing slash matches only folder content
+	ExistsPrefix(URL string) (bool, error)
+
 	//Object returns a Object for supplied url
 	StorageObject(URL string) (Object, error)
 
 	//Download returns reader for downloaded storage object
 	Download(object Object) (io.Reader, error)
 
+	//DownloadRange returns reader for length bytes of storage object starting at offset, length <= 0 reads to the end
+	DownloadRange(object Object, offset, length int64) (io.Reader, error)
+
+	//ChecksumMD5 returns hex encoded MD5 digest of storage object content
+	ChecksumMD5(object Object) (string, error)
+
+	//ChecksumSHA256 returns hex encoded SHA-256 digest of storage object content
+	ChecksumSHA256(object Object) (string, error)
+
+	//CreateFolder creates folder hierarchy for supplied URL, backends with virtual folders (object stores) treat it as no-op
+	CreateFolder(URL string) error
+
 	//Upload uploads provided reader content for supplied storage object.
 	Upload(URL string, reader io.Reader) error
 
+	//UploadWithOptions uploads provided reader content for supplied URL with content type and metadata, backends without metadata support ignore options
+	UploadWithOptions(URL string, reader io.Reader, options UploadOptions) error
+
+	//DownloadWithContext returns reader for downloaded storage object, transfer is aborted with ctx.Err() once context is done
+	DownloadWithContext(ctx context.Context, object Object) (io.Reader, error)
+
+	//UploadWithContext uploads provided reader content for supplied URL, transfer is aborted with ctx.Err() once context is done
+	UploadWithContext(ctx context.Context, URL string, reader io.Reader) error
+
 	//Delete removes passed in storage object
 	Delete(object Object) error
 
+	//DeleteAll removes passed in storage objects, it returns an error identifying all objects that failed to be removed
+	DeleteAll(objects []Object) error
+
+	//Copy streams object content from source URL to destination URL
+	Copy(sourceURL, destinationURL string) error
+
+	//Move moves object from source URL to destination URL, source is removed only after successful upload
+	Move(sourceURL, destinationURL string) error
+
 	//Register register schema with provided service
 	Register(schema string, service Service) error
 
@@ -50,27 +106,69 @@ func (s *storageService) getServiceForSchema(URL string) (Service, error) {
 	return nil, fmt.Errorf("failed to lookup url schema %v in %v", parsedUrl.Scheme, URL)
 }
 
+//getServiceForURL returns service for normalized URL scheme and normalized URL
+func (s *storageService) getServiceForURL(URL string) (Service, string, error) {
+	normalizedURL, err := NormalizeURL(URL)
+	if err != nil {
+		return nil, "", err
+	}
+	service, err := s.getServiceForSchema(normalizedURL)
+	return service, normalizedURL, err
+}
+
 //List lists all object for passed in URL
 func (s *storageService) List(URL string) ([]Object, error) {
-	service, err := s.getServiceForSchema(URL)
+	service, URL, err := s.getServiceForURL(URL)
 	if err != nil {
 		return nil, err
 	}
 	return service.List(URL)
 }
 
+//ListMatch returns objects for supplied url whose base name matches shell pattern
+func (s *storageService) ListMatch(URL, pattern string) ([]Object, error) {
+	return ListMatch(s, URL, pattern)
+}
+
+//ListRecursive lists all objects under passed in URL
+func (s *storageService) ListRecursive(URL string) ([]Object, error) {
+	service, URL, err := s.getServiceForURL(URL)
+	if err != nil {
+		return nil, err
+	}
+	return service.ListRecursive(URL)
+}
+
+//Walk visits all objects under passed in URL
+func (s *storageService) Walk(URL string, visitor WalkVisitor) error {
+	service, URL, err := s.getServiceForURL(URL)
+	if err != nil {
+		return err
+	}
+	return service.Walk(URL, visitor)
+}
+
 //Exists returns true if resource exists
 func (s *storageService) Exists(URL string) (bool, error) {
-	service, err := s.getServiceForSchema(URL)
+	service, URL, err := s.getServiceForURL(URL)
 	if err != nil {
 		return false, err
 	}
 	return service.Exists(URL)
 }
 
+//ExistsPrefix returns true if at least one object URL starts with supplied URL
+func (s *storageService) ExistsPrefix(URL string) (bool, error) {
+	service, URL, err := s.getServiceForURL(URL)
+	if err != nil {
+		return false, err
+	}
+	return service.ExistsPrefix(URL)
+}
+
 //StorageObject returns storage object for provided URL
 func (s *storageService) StorageObject(URL string) (Object, error) {
-	service, err := s.getServiceForSchema(URL)
+	service, URL, err := s.getServiceForURL(URL)
 	if err != nil {
 		return nil, err
 	}
@@ -79,31 +177,147 @@ func (s *storageService) StorageObject(URL string) (Object, error) {
 
 //Download downloads content for passed in object
 func (s *storageService) Download(object Object) (io.Reader, error) {
-	service, err := s.getServiceForSchema(object.URL())
+	service, _, err := s.getServiceForURL(object.URL())
 	if err != nil {
 		return nil, err
 	}
 	return service.Download(object)
 }
 
+//DownloadRange downloads content window for passed in object
+func (s *storageService) DownloadRange(object Object, offset, length int64) (io.Reader, error) {
+	service, _, err := s.getServiceForURL(object.URL())
+	if err != nil {
+		return nil, err
+	}
+	return service.DownloadRange(object, offset, length)
+}
+
+//ChecksumMD5 returns MD5 hex digest of passed in object content
+func (s *storageService) ChecksumMD5(object Object) (string, error) {
+	service, _, err := s.getServiceForURL(object.URL())
+	if err != nil {
+		return "", err
+	}
+	return service.ChecksumMD5(object)
+}
+
+//ChecksumSHA256 returns SHA-256 hex digest of passed in object content
+func (s *storageService) ChecksumSHA256(object Object) (string, error) {
+	service, _, err := s.getServiceForURL(object.URL())
+	if err != nil {
+		return "", err
+	}
+	return service.ChecksumSHA256(object)
+}
+
+//CreateFolder creates folder hierarchy for passed in URL
+func (s *storageService) CreateFolder(URL string) error {
+	service, URL, err := s.getServiceForURL(URL)
+	if err != nil {
+		return err
+	}
+	return service.CreateFolder(URL)
+}
+
 //Uploads content for passed in URL
 func (s *storageService) Upload(URL string, reader io.Reader) error {
-	service, err := s.getServiceForSchema(URL)
+	service, URL, err := s.getServiceForURL(URL)
 	if err != nil {
 		return err
 	}
 	return service.Upload(URL, reader)
 }
 
+//UploadWithOptions uploads content with options for passed in URL
+func (s *storageService) UploadWithOptions(URL string, reader io.Reader, options UploadOptions) error {
+	service, URL, err := s.getServiceForURL(URL)
+	if err != nil {
+		return err
+	}
+	return service.UploadWithOptions(URL, reader, options)
+}
+
+//DownloadWithContext downloads content for passed in object
+func (s *storageService) DownloadWithContext(ctx context.Context, object Object) (io.Reader, error) {
+	service, _, err := s.getServiceForURL(object.URL())
+	if err != nil {
+		return nil, err
+	}
+	return service.DownloadWithContext(ctx, object)
+}
+
+//UploadWithContext uploads content for passed in URL
+func (s *storageService) UploadWithContext(ctx context.Context, URL string, reader io.Reader) error {
+	service, URL, err := s.getServiceForURL(URL)
+	if err != nil {
+		return err
+	}
+	return service.UploadWithContext(ctx, URL, reader)
+}
+
 //Delete remove storage object
 func (s *storageService) Delete(object Object) error {
-	service, err := s.getServiceForSchema(object.URL())
+	service, _, err := s.getServiceForURL(object.URL())
 	if err != nil {
 		return err
 	}
 	return service.Delete(object)
 }
 
+//Copy copies object from source URL to destination URL, source and destination URL can use different schemes
+func (s *storageService) Copy(sourceURL, destinationURL string) error {
+	sourceService, sourceURL, err := s.getServiceForURL(sourceURL)
+	if err != nil {
+		return err
+	}
+	destinationService, destinationURL, err := s.getServiceForURL(destinationURL)
+	if err != nil {
+		return err
+	}
+	return CopyObject(sourceService, sourceURL, destinationService, destinationURL)
+}
+
+//Move moves object from source URL to destination URL, source and destination URL can use different schemes
+func (s *storageService) Move(sourceURL, destinationURL string) error {
+	sourceService, sourceURL, err := s.getServiceForURL(sourceURL)
+	if err != nil {
+		return err
+	}
+	destinationService, destinationURL, err := s.getServiceForURL(destinationURL)
+	if err != nil {
+		return err
+	}
+	if sourceService == destinationService {
+		return sourceService.Move(sourceURL, destinationURL)
+	}
+	return MoveObject(sourceService, sourceURL, destinationService, destinationURL)
+}
+
+//DeleteAll removes storage objects, objects are grouped by scheme service
+func (s *storageService) DeleteAll(objects []Object) error {
+	var services = make([]Service, 0)
+	var serviceObjects = make(map[Service][]Object)
+	var failures = make([]string, 0)
+	for _, object := range objects {
+		service, _, err := s.getServiceForURL(object.URL())
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%v: %v", object.URL(), err))
+			continue
+		}
+		if _, ok := serviceObjects[service]; !ok {
+			services = append(services, service)
+		}
+		serviceObjects[service] = append(serviceObjects[service], object)
+	}
+	for _, service := range services {
+		if err := service.DeleteAll(serviceObjects[service]); err != nil {
+			failures = append(failures, err.Error())
+		}
+	}
+	return newDeleteAllError(failures)
+}
+
 //Close closes resources
 func (s *storageService) Close() error {
 	for _, service := range s.registry {
@@ -121,6 +335,87 @@ func (s *storageService) Register(schema string, service Service) error {
 	return nil
 }
 
+//prefixPath returns URL path used for prefix matching, trailing slash is preserved
+func prefixPath(URL string) string {
+	var result = urlPath(URL)
+	if strings.HasSuffix(URL, "/") {
+		result += "/"
+	}
+	return result
+}
+
+//ExistsPrefix lists parent folder of supplied URL (or URL itself if it has trailing slash) and returns true if at least one object path starts with URL path
+func ExistsPrefix(service Service, URL string) (bool, error) {
+	var listURL = URL
+	if !strings.HasSuffix(URL, "/") {
+		listURL, _ = toolbox.URLSplit(URL)
+	}
+	objects, err := service.List(listURL)
+	if err != nil {
+		return false, err
+	}
+	var prefix = prefixPath(URL)
+	var listURLPath = urlPath(listURL)
+	for _, object := range objects {
+		var objectPath = urlPath(object.URL())
+		if objectPath == listURLPath && object.IsFolder() {
+			continue
+		}
+		if strings.HasPrefix(objectPath, prefix) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+//ListMatch lists objects for passed in URL whose base name matches supplied shell pattern (see filepath.Match)
+func ListMatch(service Service, URL, pattern string) ([]Object, error) {
+	if _, err := filepath.Match(pattern, ""); err != nil {
+		return nil, fmt.Errorf("invalid pattern %v: %v", pattern, err)
+	}
+	objects, err := service.List(URL)
+	if err != nil {
+		return nil, err
+	}
+	var URLPath = urlPath(URL)
+	var result = make([]Object, 0)
+	for _, object := range objects {
+		if object.IsFolder() && urlPath(object.URL()) == URLPath {
+			continue
+		}
+		_, name := path.Split(urlPath(object.URL()))
+		matched, err := filepath.Match(pattern, name)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %v: %v", pattern, err)
+		}
+		if matched {
+			result = append(result, object)
+		}
+	}
+	return result, nil
+}
+
+//ListModifiedSince lists objects for passed in URL modified after since time, objects with unknown (zero) modification time are excluded
+func ListModifiedSince(service Service, URL string, since time.Time) ([]Object, error) {
+	objects, err := service.List(URL)
+	if err != nil {
+		return nil, err
+	}
+	var URLPath = urlPath(URL)
+	var result = make([]Object, 0)
+	for _, object := range objects {
+		if object.IsFolder() && urlPath(object.URL()) == URLPath {
+			continue
+		}
+		var modTime = object.ModTime()
+		if modTime.IsZero() || !modTime.After(since) {
+			continue
+		}
+		result = append(result, object)
+	}
+	return result, nil
+}
+
 //NewService creates a new storage service
 func NewService() Service {
 	var result = &storageService{
@@ -131,26 +426,60 @@ func NewService() Service {
 	return result
 }
 
-//NewServiceForURL creates a new storage service for provided URL scheme and optional credential file
+//NewServiceForURL creates a new storage service for provided URL scheme and optional credential file,
+//credential file is passed to scheme credential file provider, if scheme has only config provider registered, credential file is loaded and decoded into config passed to NewServiceForURLWithConfig
 func NewServiceForURL(URL, credentialFile string) (Service, error) {
 	parsedURL, err := url.Parse(URL)
 	if err != nil {
 		return nil, err
 	}
-	service := NewService()
-	provider := NewStorageProvider().Get(parsedURL.Scheme)
-
-	if provider != nil {
+	var storageProvider = NewStorageProvider()
+	if provider := storageProvider.Get(parsedURL.Scheme); provider != nil {
+		service := NewService()
 		serviceForScheme, err := provider(credentialFile)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get storage for url %v: %v", URL, err)
 		}
-		err = service.Register(parsedURL.Scheme, serviceForScheme)
-		if err != nil {
+		return service, service.Register(parsedURL.Scheme, serviceForScheme)
+	}
+	var config interface{}
+	if credentialFile != "" && storageProvider.GetWithConfig(parsedURL.Scheme) != nil {
+		if config, err = LoadCredentialConfig(credentialFile); err != nil {
 			return nil, err
 		}
-	} else if parsedURL.Scheme != "file" {
+	}
+	return NewServiceForURLWithConfig(URL, config)
+}
+
+//NewServiceForURLWithConfig creates a new storage service for provided URL scheme passing structured credential config (i.e. decoded map or backend config struct) to provider
+func NewServiceForURLWithConfig(URL string, config interface{}) (Service, error) {
+	parsedURL, err := url.Parse(URL)
+	if err != nil {
+		return nil, err
+	}
+	service := NewService()
+	var storageProvider = NewStorageProvider()
+	var configProvider = storageProvider.GetWithConfig(parsedURL.Scheme)
+	var provider = storageProvider.Get(parsedURL.Scheme)
+	var serviceForScheme Service
+	switch {
+	case configProvider != nil:
+		serviceForScheme, err = configProvider(config)
+	case provider != nil:
+		if config != nil {
+			return nil, fmt.Errorf("failed to get storage for url %v: %v provider does not support credential config", URL, parsedURL.Scheme)
+		}
+		serviceForScheme, err = provider("")
+	case parsedURL.Scheme == "file":
+		return service, nil
+	default:
 		return nil, fmt.Errorf("unsupported scheme %v", URL)
 	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get storage for url %v: %v", URL, err)
+	}
+	if err = service.Register(parsedURL.Scheme, serviceForScheme); err != nil {
+		return nil, err
+	}
 	return service, nil
 }
@@ -0,0 +1,37 @@
+package storage
+
+import (
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"hash"
+	"io"
+)
+
+func checksum(reader io.Reader, hasher hash.Hash) (string, error) {
+	if closer, ok := reader.(io.Closer); ok {
+		defer closer.Close()
+	}
+	if _, err := io.Copy(hasher, reader); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+func downloadChecksum(service Service, object Object, hasher hash.Hash) (string, error) {
+	reader, err := service.Download(object)
+	if err != nil {
+		return "", err
+	}
+	return checksum(reader, hasher)
+}
+
+//ChecksumMD5 streams object content downloaded with supplied service through MD5 hash, it returns hex encoded digest
+func ChecksumMD5(service Service, object Object) (string, error) {
+	return downloadChecksum(service, object, md5.New())
+}
+
+//ChecksumSHA256 streams object content downloaded with supplied service through SHA-256 hash, it returns hex encoded digest
+func ChecksumSHA256(service Service, object Object) (string, error) {
+	return downloadChecksum(service, object, sha256.New())
+}
@@ -0,0 +1,51 @@
+package storage_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/toolbox"
+	"github.com/viant/toolbox/storage"
+	"os"
+	"path"
+	"strings"
+	"testing"
+)
+
+func TestService_Checksum(t *testing.T) {
+	service := storage.NewService()
+	var baseDir = path.Join(os.TempDir(), "storage_checksum_test")
+	defer os.RemoveAll(baseDir)
+	var md5Digest = "900150983cd24fb0d6963f7d28e17f72"
+	var sha256Digest = "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"
+
+	for _, URL := range []string{"mem:///checksum_test/abc.txt", toolbox.FileSchema + path.Join(baseDir, "abc.txt")} {
+		err := service.Upload(URL, strings.NewReader("abc"))
+		assert.Nil(t, err)
+		object, err := service.StorageObject(URL)
+		if !assert.Nil(t, err) {
+			continue
+		}
+		digest, err := service.ChecksumMD5(object)
+		assert.Nil(t, err)
+		assert.Equal(t, md5Digest, digest, URL)
+
+		digest, err = service.ChecksumSHA256(object)
+		assert.Nil(t, err)
+		assert.Equal(t, sha256Digest, digest, URL)
+
+		digest, err = storage.ChecksumSHA256(service, object)
+		assert.Nil(t, err)
+		assert.Equal(t, sha256Digest, digest, URL)
+	}
+
+	{ //empty content
+		var URL = toolbox.FileSchema + path.Join(baseDir, "empty.txt")
+		err := service.Upload(URL, strings.NewReader(""))
+		assert.Nil(t, err)
+		object, err := service.StorageObject(URL)
+		if assert.Nil(t, err) {
+			digest, err := storage.ChecksumMD5(service, object)
+			assert.Nil(t, err)
+			assert.Equal(t, "d41d8cd98f00b204e9800998ecf8427e", digest)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package storage
+
+import (
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"path"
+)
+
+//sniffLength represents number of leading bytes used to detect content type
+const sniffLength = 512
+
+//ContentTyper represents an object that knows its stored content type
+type ContentTyper interface {
+	//ContentType returns stored content type or empty string if unknown
+	ContentType() string
+}
+
+//ContentType returns object content type, stored content type is preferred, then type inferred from URL extension,
+//otherwise content type is detected from leading object bytes downloaded with service, folders and objects that can not be read return empty string
+func ContentType(service Service, object Object) string {
+	if typer, ok := object.(ContentTyper); ok {
+		if result := typer.ContentType(); result != "" {
+			return result
+		}
+	}
+	if result := mime.TypeByExtension(path.Ext(urlPath(object.URL()))); result != "" {
+		return result
+	}
+	if object.IsFolder() {
+		return ""
+	}
+	reader, err := service.DownloadRange(object, 0, sniffLength)
+	if err != nil {
+		return ""
+	}
+	if closer, ok := reader.(io.Closer); ok {
+		defer closer.Close()
+	}
+	content, err := ioutil.ReadAll(io.LimitReader(reader, sniffLength))
+	if err != nil {
+		return ""
+	}
+	return http.DetectContentType(content)
+}
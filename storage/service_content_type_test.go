@@ -0,0 +1,80 @@
+package storage_test
+
+import (
+	"bytes"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/toolbox"
+	"github.com/viant/toolbox/storage"
+)
+
+func TestContentType(t *testing.T) {
+	var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+	var baseDir = path.Join(os.TempDir(), "content_type_test")
+	defer os.RemoveAll(baseDir)
+	var memService = storage.NewMemoryServiceWithLimit(0)
+
+	var useCases = []struct {
+		description string
+		service     storage.Service
+		URL         string
+		content     []byte
+		options     storage.UploadOptions
+		expected    string
+	}{
+		{
+			description: "json extension",
+			service:     storage.NewFileStorage(),
+			URL:         toolbox.FileSchema + path.Join(baseDir, "data.json"),
+			content:     []byte(`{"a":1}`),
+			expected:    "application/json",
+		},
+		{
+			description: "png extension",
+			service:     storage.NewFileStorage(),
+			URL:         toolbox.FileSchema + path.Join(baseDir, "image.png"),
+			content:     pngHeader,
+			expected:    "image/png",
+		},
+		{
+			description: "unknown extension with png content",
+			service:     storage.NewFileStorage(),
+			URL:         toolbox.FileSchema + path.Join(baseDir, "image.unknownext"),
+			content:     pngHeader,
+			expected:    "image/png",
+		},
+		{
+			description: "unknown extension with text content",
+			service:     memService,
+			URL:         "mem:///content_type_test/notes.unknownext",
+			content:     []byte("plain notes"),
+			expected:    "text/plain; charset=utf-8",
+		},
+		{
+			description: "stored content type",
+			service:     memService,
+			URL:         "mem:///content_type_test/report.json",
+			content:     []byte(`{"a":1}`),
+			options:     storage.UploadOptions{ContentType: "application/vnd.report+json"},
+			expected:    "application/vnd.report+json",
+		},
+	}
+	for _, useCase := range useCases {
+		err := useCase.service.UploadWithOptions(useCase.URL, bytes.NewReader(useCase.content), useCase.options)
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		object, err := useCase.service.StorageObject(useCase.URL)
+		if assert.Nil(t, err, useCase.description) {
+			assert.Equal(t, useCase.expected, storage.ContentType(useCase.service, object), useCase.description)
+		}
+	}
+
+	folder, err := memService.StorageObject("mem:///content_type_test")
+	if assert.Nil(t, err) {
+		assert.Equal(t, "", storage.ContentType(memService, folder))
+	}
+}
@@ -0,0 +1,59 @@
+package storage
+
+import (
+	"context"
+	"io"
+)
+
+//contextReader represents a reader that fails with context error once context is done
+type contextReader struct {
+	ctx    context.Context
+	reader io.Reader
+}
+
+func (r *contextReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return r.reader.Read(p)
+}
+
+//Close closes underlying reader if it implements io.Closer
+func (r *contextReader) Close() error {
+	return closeReader(r.reader)
+}
+
+//NewContextReader returns a reader that returns ctx.Err() on read once passed in context is done
+func NewContextReader(ctx context.Context, reader io.Reader) io.Reader {
+	return &contextReader{ctx: ctx, reader: reader}
+}
+
+//DownloadWithContext downloads supplied object with service, returned reader fails with ctx.Err() once context is done
+func DownloadWithContext(ctx context.Context, service Service, object Object) (io.Reader, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	reader, err := service.Download(object)
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		if err == nil {
+			closeReader(reader)
+		}
+		return nil, ctxErr
+	}
+	if err != nil {
+		return nil, err
+	}
+	return NewContextReader(ctx, reader), nil
+}
+
+//UploadWithContext uploads supplied reader with service, upload fails with ctx.Err() once context is done
+func UploadWithContext(ctx context.Context, service Service, URL string, reader io.Reader) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	err := service.Upload(URL, NewContextReader(ctx, reader))
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return ctxErr
+	}
+	return err
+}
@@ -0,0 +1,108 @@
+package storage_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/toolbox"
+	"github.com/viant/toolbox/storage"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"strings"
+	"testing"
+	"time"
+)
+
+//cancellingReader cancels context after the first read
+type cancellingReader struct {
+	cancel context.CancelFunc
+	reads  int
+}
+
+func (r *cancellingReader) Read(p []byte) (int, error) {
+	r.reads++
+	if r.reads == 1 {
+		r.cancel()
+	}
+	var chunk = []byte(strings.Repeat("x", 1024))
+	return copy(p, chunk), nil
+}
+
+func TestService_UploadWithContext(t *testing.T) {
+	service := storage.NewService()
+	var baseDir = path.Join(os.TempDir(), "storage_context_test")
+	defer os.RemoveAll(baseDir)
+	var filePath = path.Join(baseDir, "upload.txt")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	err := service.UploadWithContext(ctx, toolbox.FileSchema+filePath, &cancellingReader{cancel: cancel})
+	assert.Equal(t, context.Canceled, err)
+	assert.False(t, toolbox.FileExists(filePath))
+
+	err = service.UploadWithContext(context.Background(), toolbox.FileSchema+filePath, strings.NewReader("abc"))
+	assert.Nil(t, err)
+	assert.True(t, toolbox.FileExists(filePath))
+
+	ctx, cancel = context.WithCancel(context.Background())
+	err = service.UploadWithContext(ctx, "mem:///context_test/upload.txt", &cancellingReader{cancel: cancel})
+	assert.Equal(t, context.Canceled, err)
+}
+
+func TestService_DownloadWithContext(t *testing.T) {
+	service := storage.NewService()
+	var baseDir = path.Join(os.TempDir(), "storage_context_test")
+	defer os.RemoveAll(baseDir)
+	var fileURL = toolbox.FileSchema + path.Join(baseDir, "download.txt")
+	err := service.Upload(fileURL, strings.NewReader("abc"))
+	assert.Nil(t, err)
+
+	object, err := service.StorageObject(fileURL)
+	if assert.Nil(t, err) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err = service.DownloadWithContext(ctx, object)
+		assert.Equal(t, context.Canceled, err)
+	}
+
+	var memURL = "mem:///context_test/download.txt"
+	err = service.Upload(memURL, strings.NewReader("abcdef"))
+	assert.Nil(t, err)
+	object, err = service.StorageObject(memURL)
+	if assert.Nil(t, err) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		reader, err := service.DownloadWithContext(ctx, object)
+		if assert.Nil(t, err) {
+			var buffer = make([]byte, 2)
+			read, err := reader.Read(buffer)
+			assert.Nil(t, err)
+			assert.Equal(t, "ab", string(buffer[:read]))
+			cancel()
+			_, err = reader.Read(buffer)
+			assert.Equal(t, context.Canceled, err)
+		}
+	}
+}
+
+func TestHttpStorageService_DownloadWithContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if request.Method == "GET" {
+			select {
+			case <-request.Context().Done():
+			case <-time.After(2 * time.Second):
+			}
+		}
+		writer.Write([]byte("late"))
+	}))
+	defer server.Close()
+	service := storage.NewHttpStorageService(nil)
+	object, err := service.StorageObject(server.URL + "/slow.txt")
+	if !assert.Nil(t, err) {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = service.DownloadWithContext(ctx, object)
+	assert.Equal(t, context.DeadlineExceeded, err)
+}
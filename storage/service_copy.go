@@ -2,10 +2,13 @@ package storage
 
 import (
 	"archive/zip"
+	"errors"
 	"fmt"
 	"github.com/viant/toolbox"
 	"io"
+	"io/ioutil"
 	"path"
+	"sort"
 	"strings"
 )
 
@@ -70,9 +73,11 @@ func copy(sourceService Service, sourceURL string, destinationService Service, d
 				err = fmt.Errorf("Unable download, %v -> %v, %v", object.URL(), destinationObjectURL, err)
 				return err
 			}
+			var downloaded = reader
 			if modifyContentHandler != nil {
 				reader, err = modifyContentHandler(reader)
 				if err != nil {
+					closeReader(downloaded)
 					err = fmt.Errorf("Unable modify content, %v %v %v", object.URL(), destinationObjectURL, err)
 					return err
 				}
@@ -95,6 +100,7 @@ func copy(sourceService Service, sourceURL string, destinationService Service, d
 				}
 			}
 			err = copyHandler(object, reader, destinationService, destinationObjectURL)
+			closeReader(downloaded)
 			if err != nil {
 				return err
 			}
@@ -109,6 +115,191 @@ func copy(sourceService Service, sourceURL string, destinationService Service, d
 	return nil
 }
 
+func sortObjects(objects []Object) {
+	sort.Slice(objects, func(i, j int) bool {
+		return objects[i].URL() < objects[j].URL()
+	})
+}
+
+func listRecursive(service Service, URL string, result *[]Object) error {
+	objects, err := service.List(URL)
+	if err != nil {
+		return err
+	}
+	var URLPath = urlPath(URL)
+	for _, object := range objects {
+		if urlPath(object.URL()) == URLPath {
+			if object.IsContent() {
+				*result = append(*result, object)
+			}
+			continue
+		}
+		*result = append(*result, object)
+		if object.IsFolder() {
+			if err = listRecursive(service, object.URL(), result); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+//ListRecursive walks supplied service sub folders and returns all objects under passed in URL sorted by URL, folders can be identified with IsFolder
+func ListRecursive(service Service, URL string) ([]Object, error) {
+	var result = make([]Object, 0)
+	if err := listRecursive(service, URL, &result); err != nil {
+		return nil, err
+	}
+	sortObjects(result)
+	return result, nil
+}
+
+//StopWalkError is returned by a walk visitor to stop walking, walk itself does not return it
+var StopWalkError = errors.New("stop walk")
+
+//WalkVisitor represents a walk callback, returned error other than StopWalkError aborts walk with that error
+type WalkVisitor func(object Object) error
+
+func walk(service Service, URL string, visitor WalkVisitor) error {
+	objects, err := service.List(URL)
+	if err != nil {
+		return err
+	}
+	var URLPath = urlPath(URL)
+	for _, object := range objects {
+		if urlPath(object.URL()) == URLPath {
+			if object.IsContent() {
+				if err = visitor(object); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if err = visitor(object); err != nil {
+			return err
+		}
+		if object.IsFolder() {
+			if err = walk(service, object.URL(), visitor); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+//Walk walks supplied service sub folders listing one folder at a time and calls visitor with each object under passed in URL,
+//walk stops once visitor returns an error, StopWalkError stops walk without error
+func Walk(service Service, URL string, visitor WalkVisitor) error {
+	if err := walk(service, URL, visitor); err != nil && err != StopWalkError {
+		return err
+	}
+	return nil
+}
+
+func checkDownloadRange(object Object, size, offset int64) error {
+	if offset < 0 || offset > size {
+		return fmt.Errorf("offset %v is out of range for %v with size %v", offset, object.URL(), size)
+	}
+	return nil
+}
+
+//DownloadRange downloads supplied object with service, skips offset bytes and limits reader to length bytes (length <= 0 reads to the end)
+func DownloadRange(service Service, object Object, offset, length int64) (io.Reader, error) {
+	if offset < 0 {
+		return nil, checkDownloadRange(object, 0, offset)
+	}
+	reader, err := service.Download(object)
+	if err != nil {
+		return nil, err
+	}
+	skipped, err := io.CopyN(ioutil.Discard, reader, offset)
+	if err == io.EOF {
+		closeReader(reader)
+		return nil, checkDownloadRange(object, skipped, offset)
+	}
+	if err != nil {
+		closeReader(reader)
+		return nil, err
+	}
+	if length > 0 {
+		return &readCloser{Reader: io.LimitReader(reader, length), source: reader}, nil
+	}
+	return reader, nil
+}
+
+//readCloser represents a reader that closes source reader if it implements io.Closer
+type readCloser struct {
+	io.Reader
+	source io.Reader
+}
+
+func (r *readCloser) Close() error {
+	return closeReader(r.source)
+}
+
+//closeReader closes supplied reader if it implements io.Closer
+func closeReader(reader io.Reader) error {
+	if closer, ok := reader.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+func newDeleteAllError(failures []string) error {
+	if len(failures) == 0 {
+		return nil
+	}
+	return fmt.Errorf("failed to delete %v object(s):\n\t%v", len(failures), strings.Join(failures, "\n\t"))
+}
+
+//DeleteAll removes passed in objects one by one with supplied service, failures are aggregated into a single error listing each failed URL
+func DeleteAll(service Service, objects []Object) error {
+	var failures = make([]string, 0)
+	for _, object := range objects {
+		if err := service.Delete(object); err != nil {
+			failures = append(failures, fmt.Sprintf("%v: %v", object.URL(), err))
+		}
+	}
+	return newDeleteAllError(failures)
+}
+
+//CopyObject streams content of source URL object from source service to destination URL on destination service
+func CopyObject(sourceService Service, sourceURL string, destinationService Service, destinationURL string) error {
+	object, err := sourceService.StorageObject(sourceURL)
+	if err != nil {
+		return fmt.Errorf("failed to lookup %v: %v", sourceURL, err)
+	}
+	if !object.IsContent() {
+		return fmt.Errorf("failed to copy %v: source is not a content object", sourceURL)
+	}
+	reader, err := sourceService.Download(object)
+	if err != nil {
+		return fmt.Errorf("failed to download %v: %v", sourceURL, err)
+	}
+	if closer, ok := reader.(io.Closer); ok {
+		defer closer.Close()
+	}
+	if err = destinationService.Upload(destinationURL, reader); err != nil {
+		return fmt.Errorf("failed to upload %v -> %v: %v", sourceURL, destinationURL, err)
+	}
+	return nil
+}
+
+//MoveObject copies source URL object to destination URL and then removes the source object
+func MoveObject(sourceService Service, sourceURL string, destinationService Service, destinationURL string) error {
+	if err := CopyObject(sourceService, sourceURL, destinationService, destinationURL); err != nil {
+		return err
+	}
+	object, err := sourceService.StorageObject(sourceURL)
+	if err == nil {
+		err = sourceService.Delete(object)
+	}
+	if err != nil {
+		return fmt.Errorf("copied %v -> %v, but failed to remove source: %v", sourceURL, destinationURL, err)
+	}
+	return nil
+}
+
 func copySourceToDestination(sourceObject Object, reader io.Reader, destinationService Service, destinationURL string) error {
 	err := destinationService.Upload(destinationURL, reader)
 	if err != nil {
@@ -0,0 +1,98 @@
+package storage
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"strings"
+)
+
+//GzipExtension represents compressed object URL extension
+const GzipExtension = ".gz"
+
+//compressReader represents a reader that gzip compresses source content as it is read, no goroutine is involved so abandoned reader does not leak
+type compressReader struct {
+	source io.Reader
+	buffer *bytes.Buffer
+	writer *gzip.Writer
+	chunk  []byte
+	eof    bool
+}
+
+func (r *compressReader) Read(p []byte) (int, error) {
+	for r.buffer.Len() == 0 {
+		if r.eof {
+			return 0, io.EOF
+		}
+		n, err := r.source.Read(r.chunk)
+		if n > 0 {
+			if _, writeErr := r.writer.Write(r.chunk[:n]); writeErr != nil {
+				return 0, writeErr
+			}
+		}
+		if err == io.EOF {
+			if err = r.writer.Close(); err != nil {
+				return 0, err
+			}
+			r.eof = true
+		} else if err != nil {
+			return 0, err
+		}
+	}
+	return r.buffer.Read(p)
+}
+
+func newCompressReader(source io.Reader) io.Reader {
+	var buffer = new(bytes.Buffer)
+	return &compressReader{
+		source: source,
+		buffer: buffer,
+		writer: gzip.NewWriter(buffer),
+		chunk:  make([]byte, 32*1024),
+	}
+}
+
+//CompressedUpload returns URL with .gz extension and reader streaming gzip compressed content if options.Compress is set, otherwise passed in URL and reader are returned
+func CompressedUpload(URL string, reader io.Reader, options UploadOptions) (string, io.Reader) {
+	if !options.Compress {
+		return URL, reader
+	}
+	if !strings.HasSuffix(URL, GzipExtension) {
+		URL += GzipExtension
+	}
+	return URL, newCompressReader(reader)
+}
+
+//decompressReader represents gzip reader that closes both gzip and downloaded source reader
+type decompressReader struct {
+	*gzip.Reader
+	source io.Reader
+}
+
+//Close closes gzip reader and underlying downloaded reader
+func (r *decompressReader) Close() error {
+	err := r.Reader.Close()
+	if closer, ok := r.source.(io.Closer); ok {
+		if closeErr := closer.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
+
+//DownloadDecompressed downloads supplied object with service, content of objects with .gz extension is transparently decompressed,
+//returned reader implements io.Closer that closes the downloaded reader as well
+func DownloadDecompressed(service Service, object Object) (io.Reader, error) {
+	reader, err := service.Download(object)
+	if err != nil || !strings.HasSuffix(object.URL(), GzipExtension) {
+		return reader, err
+	}
+	gzipReader, err := gzip.NewReader(reader)
+	if err != nil {
+		if closer, ok := reader.(io.Closer); ok {
+			closer.Close()
+		}
+		return nil, err
+	}
+	return &decompressReader{Reader: gzipReader, source: reader}, nil
+}
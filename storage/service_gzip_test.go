@@ -0,0 +1,163 @@
+package storage_test
+
+import (
+	"compress/gzip"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/toolbox"
+	"github.com/viant/toolbox/storage"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+	"runtime"
+	"testing"
+)
+
+func TestCompressedUpload(t *testing.T) {
+	var payload = strings.Repeat("compressible text artifact line\n", 1000)
+	var baseDir = path.Join(os.TempDir(), "gzip_upload_test")
+	defer os.RemoveAll(baseDir)
+
+	var useCases = []struct {
+		description string
+		service     storage.Service
+		URL         string
+		storedSize  bool
+	}{
+		{
+			description: "memory service",
+			service:     storage.NewMemoryService(),
+			URL:         "mem:///gzip_test/data.txt",
+			storedSize:  true,
+		},
+		{
+			description: "file service",
+			service:     storage.NewFileStorage(),
+			URL:         toolbox.FileSchema + path.Join(baseDir, "data.txt"),
+			storedSize:  true,
+		},
+		{
+			description: "encrypted memory service",
+			service:     storage.NewEncryptedService(storage.NewMemoryService(), []byte("0123456789abcdef")),
+			URL:         "mem:///gzip_test/encrypted.txt",
+		},
+	}
+
+	for _, useCase := range useCases {
+		service := useCase.service
+		err := service.UploadWithOptions(useCase.URL, strings.NewReader(payload), storage.UploadOptions{Compress: true})
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		exists, _ := service.Exists(useCase.URL)
+		assert.False(t, exists, useCase.description)
+
+		object, err := service.StorageObject(useCase.URL + storage.GzipExtension)
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		assert.True(t, object.Size() < int64(len(payload)), useCase.description)
+
+		reader, err := service.Download(object)
+		if assert.Nil(t, err, useCase.description) {
+			stored, err := ioutil.ReadAll(reader)
+			assert.Nil(t, err, useCase.description)
+			if useCase.storedSize {
+				assert.Equal(t, object.Size(), int64(len(stored)), useCase.description)
+			}
+			gzipReader, err := gzip.NewReader(strings.NewReader(string(stored)))
+			if assert.Nil(t, err, useCase.description) {
+				content, err := ioutil.ReadAll(gzipReader)
+				assert.Nil(t, err, useCase.description)
+				assert.Equal(t, payload, string(content), useCase.description)
+			}
+		}
+
+		reader, err = storage.DownloadDecompressed(service, object)
+		if assert.Nil(t, err, useCase.description) {
+			content, err := ioutil.ReadAll(reader)
+			assert.Nil(t, err, useCase.description)
+			assert.Equal(t, payload, string(content), useCase.description)
+		}
+	}
+
+	{ //objects without .gz extension are downloaded as is, extension is not duplicated
+		service := storage.NewMemoryService()
+		err := service.Upload("mem:///gzip_test/plain.txt", strings.NewReader(payload))
+		assert.Nil(t, err)
+		object, err := service.StorageObject("mem:///gzip_test/plain.txt")
+		if assert.Nil(t, err) {
+			reader, err := storage.DownloadDecompressed(service, object)
+			assert.Nil(t, err)
+			content, err := ioutil.ReadAll(reader)
+			assert.Nil(t, err)
+			assert.Equal(t, payload, string(content))
+		}
+		err = service.UploadWithOptions("mem:///gzip_test/archive.gz", strings.NewReader(payload), storage.UploadOptions{Compress: true})
+		assert.Nil(t, err)
+		exists, err := service.Exists("mem:///gzip_test/archive.gz")
+		assert.Nil(t, err)
+		assert.True(t, exists)
+	}
+}
+
+func TestCompressedUpload_Abandoned(t *testing.T) {
+	var goroutines = runtime.NumGoroutine()
+	for i := 0; i < 10; i++ {
+		_, reader := storage.CompressedUpload("mem:///gzip_test/abandoned.txt", strings.NewReader("abc"), storage.UploadOptions{Compress: true})
+		assert.NotNil(t, reader)
+	}
+	assert.Equal(t, goroutines, runtime.NumGoroutine())
+
+	_, reader := storage.CompressedUpload("mem:///gzip_test/failed.txt", &failingReader{}, storage.UploadOptions{Compress: true})
+	_, err := ioutil.ReadAll(reader)
+	assert.NotNil(t, err)
+}
+
+//closeTrackingReader records whether it was closed
+type closeTrackingReader struct {
+	io.Reader
+	closed bool
+}
+
+func (r *closeTrackingReader) Close() error {
+	r.closed = true
+	return nil
+}
+
+type closeTrackingService struct {
+	storage.Service
+	reader *closeTrackingReader
+}
+
+func (s *closeTrackingService) Download(object storage.Object) (io.Reader, error) {
+	reader, err := s.Service.Download(object)
+	if err != nil {
+		return nil, err
+	}
+	s.reader = &closeTrackingReader{Reader: reader}
+	return s.reader, nil
+}
+
+func TestDownloadDecompressed_Close(t *testing.T) {
+	service := &closeTrackingService{Service: storage.NewMemoryServiceWithLimit(0)}
+	err := service.UploadWithOptions("mem:///gzip_close_test/data.txt", strings.NewReader("abc"), storage.UploadOptions{Compress: true})
+	assert.Nil(t, err)
+	object, err := service.StorageObject("mem:///gzip_close_test/data.txt.gz")
+	if !assert.Nil(t, err) {
+		return
+	}
+	reader, err := storage.DownloadDecompressed(service, object)
+	if !assert.Nil(t, err) {
+		return
+	}
+	content, err := ioutil.ReadAll(reader)
+	assert.Nil(t, err)
+	assert.Equal(t, "abc", string(content))
+	closer, ok := reader.(io.Closer)
+	if assert.True(t, ok) {
+		assert.Nil(t, closer.Close())
+		assert.True(t, service.reader.closed)
+	}
+}
@@ -0,0 +1,51 @@
+package storage
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+)
+
+//DefaultMaxLineSize represents default max line size in bytes used by DownloadLines
+var DefaultMaxLineSize = 1024 * 1024
+
+//LineHandler represents a line callback, returned error stops line iteration
+type LineHandler func(line string) error
+
+//DownloadLines streams object content downloaded with supplied service and calls handler with each line (without line terminator),
+//lines longer than DefaultMaxLineSize result in an error
+func DownloadLines(service Service, object Object, handler LineHandler) error {
+	return DownloadLinesWithLimit(service, object, DefaultMaxLineSize, handler)
+}
+
+//DownloadLinesWithLimit streams object content downloaded with supplied service and calls handler with each line,
+//iteration stops with the first handler error or error for line longer than maxLineSize bytes
+func DownloadLinesWithLimit(service Service, object Object, maxLineSize int, handler LineHandler) error {
+	if maxLineSize <= 0 {
+		return fmt.Errorf("invalid max line size: %v, expected positive number of bytes", maxLineSize)
+	}
+	reader, err := service.Download(object)
+	if err != nil {
+		return err
+	}
+	if closer, ok := reader.(io.Closer); ok {
+		defer closer.Close()
+	}
+	var scanner = bufio.NewScanner(reader)
+	//scanner buffer holds line with its trailing new line
+	var bufferSize = maxLineSize + 1
+	var initialSize = bufio.MaxScanTokenSize
+	if bufferSize < initialSize {
+		initialSize = bufferSize
+	}
+	scanner.Buffer(make([]byte, 0, initialSize), bufferSize)
+	for scanner.Scan() {
+		if err = handler(scanner.Text()); err != nil {
+			return err
+		}
+	}
+	if err = scanner.Err(); err == bufio.ErrTooLong {
+		return fmt.Errorf("failed to read %v: line exceeds max line size %v bytes", object.URL(), maxLineSize)
+	}
+	return err
+}
@@ -0,0 +1,77 @@
+package storage_test
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/toolbox/storage"
+	"strings"
+	"testing"
+)
+
+func TestDownloadLines(t *testing.T) {
+	service := storage.NewMemoryServiceWithLimit(0)
+	var longLine = strings.Repeat("x", 200*1024)
+	var content = "line1\nline2\r\n\n" + longLine + "\nlast"
+	var URL = "mem:///download_lines_test/app.log"
+	assert.Nil(t, service.Upload(URL, strings.NewReader(content)))
+	object, err := service.StorageObject(URL)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	var lines = make([]string, 0)
+	err = storage.DownloadLines(service, object, func(line string) error {
+		lines = append(lines, line)
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"line1", "line2", "", longLine, "last"}, lines)
+
+	{ //handler error stops iteration
+		var count = 0
+		var stopErr = errors.New("stop")
+		err := storage.DownloadLines(service, object, func(line string) error {
+			count++
+			if count == 2 {
+				return stopErr
+			}
+			return nil
+		})
+		assert.Equal(t, stopErr, err)
+		assert.Equal(t, 2, count)
+	}
+	{ //line longer than limit
+		var count = 0
+		err := storage.DownloadLinesWithLimit(service, object, 1024, func(line string) error {
+			count++
+			return nil
+		})
+		assert.NotNil(t, err)
+		assert.Equal(t, 3, count)
+	}
+	{ //line of exactly max line size
+		var exactURL = "mem:///download_lines_test/exact.log"
+		assert.Nil(t, service.Upload(exactURL, strings.NewReader("12345\n123456\n")))
+		exactObject, err := service.StorageObject(exactURL)
+		if assert.Nil(t, err) {
+			var lines = make([]string, 0)
+			err = storage.DownloadLinesWithLimit(service, exactObject, 5, func(line string) error {
+				lines = append(lines, line)
+				return nil
+			})
+			assert.NotNil(t, err)
+			assert.Equal(t, []string{"12345"}, lines)
+		}
+	}
+	for _, maxLineSize := range []int{0, -1} { //invalid limit
+		var count = 0
+		err := storage.DownloadLinesWithLimit(service, object, maxLineSize, func(line string) error {
+			count++
+			return nil
+		})
+		if assert.NotNil(t, err) {
+			assert.True(t, strings.Contains(err.Error(), "invalid max line size"))
+		}
+		assert.Equal(t, 0, count)
+	}
+}
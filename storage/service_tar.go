@@ -0,0 +1,159 @@
+package storage
+
+import (
+	"archive/tar"
+	"fmt"
+	"github.com/viant/toolbox"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+)
+
+func tarEntryName(baseURLPath string, object Object) string {
+	var name = strings.TrimPrefix(urlPath(object.URL()), baseURLPath)
+	name = strings.TrimPrefix(name, "/")
+	if object.IsFolder() {
+		name += "/"
+	}
+	return name
+}
+
+//readerLength returns number of bytes left in reader if it can be determined without reading it, i.e. for in memory or file readers
+func readerLength(reader io.Reader) (int64, bool) {
+	switch actual := reader.(type) {
+	case interface {
+		Len() int
+	}:
+		return int64(actual.Len()), true
+	case *os.File:
+		info, err := actual.Stat()
+		if err != nil || !info.Mode().IsRegular() {
+			return 0, false
+		}
+		offset, err := actual.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return 0, false
+		}
+		return info.Size() - offset, true
+	}
+	return 0, false
+}
+
+//writeTarContent writes regular file entry, object size is not trusted as decorators (i.e. encrypted service) may change downloaded content size,
+//thus content is streamed only if reader length is known, otherwise it is spooled to a temp file to compute entry size
+func writeTarContent(archive *tar.Writer, header *tar.Header, reader io.Reader) error {
+	header.Typeflag = tar.TypeReg
+	if length, ok := readerLength(reader); ok {
+		header.Size = length
+		if err := archive.WriteHeader(header); err != nil {
+			return err
+		}
+		_, err := io.CopyN(archive, reader, length)
+		return err
+	}
+	spool, err := ioutil.TempFile("", "tar_entry")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(spool.Name())
+	defer spool.Close()
+	if header.Size, err = io.Copy(spool, reader); err != nil {
+		return err
+	}
+	if _, err = spool.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	if err = archive.WriteHeader(header); err != nil {
+		return err
+	}
+	_, err = io.CopyN(archive, spool, header.Size)
+	return err
+}
+
+func writeTar(service Service, URL string, objects []Object, writer io.Writer) error {
+	archive := tar.NewWriter(writer)
+	var baseURLPath = urlPath(URL)
+	for _, object := range objects {
+		var name = tarEntryName(baseURLPath, object)
+		if name == "" {
+			_, name = toolbox.URLSplit(object.URL())
+		}
+		header := &tar.Header{
+			Name:    name,
+			Mode:    0644,
+			ModTime: object.ModTime(),
+		}
+		if fileInfo := object.FileInfo(); fileInfo != nil {
+			header.Mode = int64(fileInfo.Mode().Perm())
+		}
+		if object.IsFolder() {
+			header.Typeflag = tar.TypeDir
+			if err := archive.WriteHeader(header); err != nil {
+				return err
+			}
+			continue
+		}
+		reader, err := service.Download(object)
+		if err != nil {
+			return fmt.Errorf("failed to download %v: %v", object.URL(), err)
+		}
+		err = writeTarContent(archive, header, reader)
+		if closer, ok := reader.(io.Closer); ok {
+			closer.Close()
+		}
+		if err != nil {
+			return fmt.Errorf("failed to archive %v: %v", object.URL(), err)
+		}
+	}
+	return archive.Close()
+}
+
+//DownloadAsTar lists supplied URL recursively with service and returns reader streaming tar archive of all objects, entry names are relative to URL.
+//Archive is written by a background goroutine, caller has to read the reader to EOF or close it to release it.
+func DownloadAsTar(service Service, URL string) (io.ReadCloser, error) {
+	objects, err := ListRecursive(service, URL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %v: %v", URL, err)
+	}
+	reader, writer := io.Pipe()
+	go func() {
+		writer.CloseWithError(writeTar(service, URL, objects, writer))
+	}()
+	return reader, nil
+}
+
+//sanitizeTarEntryName returns clean relative entry name or error if entry would be extracted outside of the destination
+func sanitizeTarEntryName(name string) (string, error) {
+	var cleaned = path.Clean(strings.Replace(name, "\\", "/", -1))
+	if path.IsAbs(cleaned) || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", fmt.Errorf("illegal tar entry path: %v", name)
+	}
+	return cleaned, nil
+}
+
+//UploadTar extracts supplied tar archive reader under destination URL with service, only regular file entries are uploaded
+func UploadTar(service Service, destinationURL string, reader io.Reader) error {
+	archive := tar.NewReader(reader)
+	for {
+		header, err := archive.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read tar: %v", err)
+		}
+		name, err := sanitizeTarEntryName(header.Name)
+		if err != nil {
+			return err
+		}
+		if header.Typeflag != tar.TypeReg && header.Typeflag != tar.TypeRegA {
+			continue
+		}
+		var entryURL = toolbox.URLPathJoin(destinationURL, name)
+		if err = service.Upload(entryURL, archive); err != nil {
+			return fmt.Errorf("failed to upload %v: %v", entryURL, err)
+		}
+	}
+}
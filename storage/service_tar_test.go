@@ -0,0 +1,163 @@
+package storage_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/toolbox"
+	"github.com/viant/toolbox/storage"
+	"io/ioutil"
+	"os"
+	"path"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDownloadAsTar(t *testing.T) {
+	service := storage.NewMemoryService()
+	var files = map[string]string{
+		"mem:///tar_source/file1.txt":         "abc",
+		"mem:///tar_source/sub/file2.txt":     "xyz",
+		"mem:///tar_source/sub/sub/file3.txt": "---",
+	}
+	for URL, content := range files {
+		err := service.Upload(URL, strings.NewReader(content))
+		assert.Nil(t, err)
+	}
+
+	{ //memory to memory round trip
+		reader, err := storage.DownloadAsTar(service, "mem:///tar_source")
+		if !assert.Nil(t, err) {
+			return
+		}
+		err = storage.UploadTar(service, "mem:///tar_target", reader)
+		assert.Nil(t, err)
+		for URL, expected := range files {
+			var targetURL = strings.Replace(URL, "tar_source", "tar_target", 1)
+			object, err := service.StorageObject(targetURL)
+			if !assert.Nil(t, err, targetURL) {
+				continue
+			}
+			reader, err := service.Download(object)
+			if assert.Nil(t, err) {
+				content, err := ioutil.ReadAll(reader)
+				assert.Nil(t, err)
+				assert.Equal(t, expected, string(content))
+			}
+		}
+	}
+
+	{ //memory to file round trip
+		fileService := storage.NewFileStorage()
+		var baseDir = path.Join(os.TempDir(), "tar_target_test")
+		defer os.RemoveAll(baseDir)
+		reader, err := storage.DownloadAsTar(service, "mem:///tar_source")
+		if !assert.Nil(t, err) {
+			return
+		}
+		err = storage.UploadTar(fileService, toolbox.FileSchema+baseDir, reader)
+		assert.Nil(t, err)
+		content, err := ioutil.ReadFile(path.Join(baseDir, "sub/sub/file3.txt"))
+		assert.Nil(t, err)
+		assert.Equal(t, "---", string(content))
+
+		reader, err = storage.DownloadAsTar(fileService, toolbox.FileSchema+baseDir)
+		if !assert.Nil(t, err) {
+			return
+		}
+		archive := tar.NewReader(reader)
+		var names = make(map[string]bool)
+		for {
+			header, err := archive.Next()
+			if err != nil {
+				break
+			}
+			names[header.Name] = true
+		}
+		assert.EqualValues(t, map[string]bool{
+			"file1.txt":         true,
+			"sub/":              true,
+			"sub/file2.txt":     true,
+			"sub/sub/":          true,
+			"sub/sub/file3.txt": true,
+		}, names)
+	}
+}
+
+func TestUploadTar_PathTraversal(t *testing.T) {
+	service := storage.NewMemoryService()
+	for _, name := range []string{"../escape.txt", "sub/../../escape.txt", "/etc/escape.txt"} {
+		var buffer = new(bytes.Buffer)
+		archive := tar.NewWriter(buffer)
+		err := archive.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: 3, Typeflag: tar.TypeReg})
+		assert.Nil(t, err)
+		_, err = archive.Write([]byte("abc"))
+		assert.Nil(t, err)
+		assert.Nil(t, archive.Close())
+
+		err = storage.UploadTar(service, "mem:///tar_traversal/target", buffer)
+		assert.NotNil(t, err, name)
+		exists, _ := service.Exists("mem:///tar_traversal/escape.txt")
+		assert.False(t, exists, name)
+	}
+
+	{ //inner parent references within destination are allowed
+		var buffer = new(bytes.Buffer)
+		archive := tar.NewWriter(buffer)
+		archive.WriteHeader(&tar.Header{Name: "sub/../inner.txt", Mode: 0644, Size: 3, Typeflag: tar.TypeReg})
+		archive.Write([]byte("abc"))
+		archive.Close()
+		err := storage.UploadTar(service, "mem:///tar_traversal/target", buffer)
+		assert.Nil(t, err)
+		exists, err := service.Exists("mem:///tar_traversal/target/inner.txt")
+		assert.Nil(t, err)
+		assert.True(t, exists)
+	}
+}
+
+func TestDownloadAsTar_Encrypted(t *testing.T) {
+	delegate := storage.NewMemoryServiceWithLimit(0)
+	encrypted := storage.NewEncryptedService(delegate, []byte("0123456789abcdef0123456789abcdef"))
+	assert.Nil(t, encrypted.Upload("mem:///tar_encrypted/secret.txt", strings.NewReader("top secret")))
+	for _, service := range []storage.Service{
+		encrypted,
+		storage.NewReadOnlyService(encrypted),
+		storage.NewInstrumentedService(storage.NewReadOnlyService(encrypted)),
+	} {
+		reader, err := storage.DownloadAsTar(service, "mem:///tar_encrypted")
+		if !assert.Nil(t, err) {
+			continue
+		}
+		archive := tar.NewReader(reader)
+		header, err := archive.Next()
+		if assert.Nil(t, err) {
+			assert.Equal(t, "secret.txt", header.Name)
+			content, err := ioutil.ReadAll(archive)
+			assert.Nil(t, err)
+			assert.Equal(t, "top secret", string(content))
+		}
+		reader.Close()
+	}
+}
+
+func TestDownloadAsTar_Close(t *testing.T) {
+	service := storage.NewMemoryServiceWithLimit(0)
+	for i := 0; i < 10; i++ {
+		assert.Nil(t, service.Upload(fmt.Sprintf("mem:///tar_close/file%v.txt", i), strings.NewReader(strings.Repeat("x", 1024))))
+	}
+	var goroutines = runtime.NumGoroutine()
+	reader, err := storage.DownloadAsTar(service, "mem:///tar_close")
+	if !assert.Nil(t, err) {
+		return
+	}
+	_, err = reader.Read(make([]byte, 10))
+	assert.Nil(t, err)
+	assert.Nil(t, reader.Close())
+	for i := 0; i < 100 && runtime.NumGoroutine() > goroutines; i++ {
+		time.Sleep(time.Millisecond)
+	}
+	assert.True(t, runtime.NumGoroutine() <= goroutines, "tar writer goroutine should exit once reader is closed")
+}
@@ -2,6 +2,7 @@ package storage_test
 
 import (
 	"bytes"
+	"fmt"
 	"github.com/stretchr/testify/assert"
 	"github.com/viant/toolbox"
 	"github.com/viant/toolbox/storage"
@@ -10,8 +11,10 @@ import (
 	"os"
 	"os/exec"
 	"path"
+	"sort"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestStorageService_List(t *testing.T) {
@@ -19,7 +22,7 @@ func TestStorageService_List(t *testing.T) {
 	assert.NotNil(t, service)
 	fileName, _, _ := toolbox.CallerInfo(2)
 	parent, _ := path.Split(fileName)
-	baseUrl := "file://" + parent + "/test"
+	baseUrl := "file://" + path.Join(parent, "test")
 
 	if toolbox.FileExists(parent + "/test/file3.txt") {
 		os.Remove(parent + "/test/file3.txt")
@@ -82,3 +85,226 @@ func TestUpload(t *testing.T) {
 	assert.Nil(t, err)
 
 }
+
+func TestStorageService_Copy(t *testing.T) {
+	service := storage.NewService()
+	var sourceURL = "mem:///copy_test/source.txt"
+	var fileURL = "file://" + path.Join(os.TempDir(), "storage_copy_test", "file.txt")
+	var targetURL = "mem:///copy_test/target/file.txt"
+	defer os.RemoveAll(path.Join(os.TempDir(), "storage_copy_test"))
+
+	err := service.Upload(sourceURL, strings.NewReader("copy me"))
+	assert.Nil(t, err)
+
+	err = service.Copy(sourceURL, fileURL)
+	assert.Nil(t, err)
+	content, err := ioutil.ReadFile(path.Join(os.TempDir(), "storage_copy_test", "file.txt"))
+	assert.Nil(t, err)
+	assert.Equal(t, "copy me", string(content))
+
+	err = service.Copy(fileURL, targetURL)
+	assert.Nil(t, err)
+	object, err := service.StorageObject(targetURL)
+	if assert.Nil(t, err) {
+		reader, err := service.Download(object)
+		if assert.Nil(t, err) {
+			content, err := ioutil.ReadAll(reader)
+			assert.Nil(t, err)
+			assert.Equal(t, "copy me", string(content))
+		}
+	}
+
+	err = service.Copy("mem:///copy_test/missing.txt", fileURL)
+	assert.NotNil(t, err)
+
+	err = service.Copy("mem:///copy_test/target", fileURL)
+	assert.NotNil(t, err)
+
+	err = service.Copy(sourceURL, "abc:///copy_test/file.txt")
+	assert.NotNil(t, err)
+}
+
+type failingDeleteService struct {
+	storage.Service
+}
+
+func (s *failingDeleteService) Delete(object storage.Object) error {
+	return fmt.Errorf("delete is not permitted")
+}
+
+func TestStorageService_Move(t *testing.T) {
+	service := storage.NewService()
+	var baseDir = path.Join(os.TempDir(), "storage_move_test")
+	defer os.RemoveAll(baseDir)
+
+	{ //cross scheme move
+		var sourceURL = "mem:///move_test/source.txt"
+		var targetURL = "file://" + path.Join(baseDir, "moved.txt")
+		err := service.Upload(sourceURL, strings.NewReader("move me"))
+		assert.Nil(t, err)
+
+		err = service.Move(sourceURL, targetURL)
+		assert.Nil(t, err)
+		exists, err := service.Exists(sourceURL)
+		assert.Nil(t, err)
+		assert.False(t, exists)
+		content, err := ioutil.ReadFile(path.Join(baseDir, "moved.txt"))
+		assert.Nil(t, err)
+		assert.Equal(t, "move me", string(content))
+	}
+	{ //same scheme rename
+		var sourceURL = "file://" + path.Join(baseDir, "moved.txt")
+		var targetURL = "file://" + path.Join(baseDir, "sub", "renamed.txt")
+		err := service.Move(sourceURL, targetURL)
+		assert.Nil(t, err)
+		assert.False(t, toolbox.FileExists(path.Join(baseDir, "moved.txt")))
+		content, err := ioutil.ReadFile(path.Join(baseDir, "sub", "renamed.txt"))
+		assert.Nil(t, err)
+		assert.Equal(t, "move me", string(content))
+	}
+	{ //failed source removal
+		var sourceURL = "file://" + path.Join(baseDir, "sub", "renamed.txt")
+		var targetURL = "mem:///move_test/target.txt"
+		err := storage.MoveObject(&failingDeleteService{storage.NewFileStorage()}, sourceURL, storage.NewMemoryService(), targetURL)
+		if assert.NotNil(t, err) {
+			assert.Contains(t, err.Error(), "copied")
+		}
+		assert.True(t, toolbox.FileExists(path.Join(baseDir, "sub", "renamed.txt")))
+		exists, err := service.Exists(targetURL)
+		assert.Nil(t, err)
+		assert.True(t, exists)
+	}
+	{
+		err := service.Move("file://"+path.Join(baseDir, "missing.txt"), "mem:///move_test/missing.txt")
+		assert.NotNil(t, err)
+	}
+}
+
+func TestListMatch(t *testing.T) {
+	service := storage.NewService()
+	var baseDir = path.Join(os.TempDir(), "storage_list_match_test")
+	defer os.RemoveAll(baseDir)
+	for _, name := range []string{"config.json", "meta.json", "data-01.csv", "data-02.csv", "data-100.csv", "readme.txt"} {
+		err := service.Upload(toolbox.FileSchema+path.Join(baseDir, name), strings.NewReader("abc"))
+		assert.Nil(t, err)
+	}
+	var baseURL = toolbox.FileSchema + baseDir
+	var names = func(objects []storage.Object) []string {
+		var result = make([]string, 0)
+		for _, object := range objects {
+			_, name := path.Split(object.URL())
+			result = append(result, name)
+		}
+		sort.Strings(result)
+		return result
+	}
+
+	objects, err := service.ListMatch(baseURL, "*.json")
+	assert.Nil(t, err)
+	assert.EqualValues(t, []string{"config.json", "meta.json"}, names(objects))
+
+	objects, err = service.ListMatch(baseURL, "data-??.csv")
+	assert.Nil(t, err)
+	assert.EqualValues(t, []string{"data-01.csv", "data-02.csv"}, names(objects))
+
+	objects, err = service.ListMatch(baseURL, "*.xml")
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(objects))
+
+	_, err = service.ListMatch(baseURL, "data-[.csv")
+	assert.NotNil(t, err)
+
+	var decorated = storage.NewReadOnlyService(storage.NewInstrumentedService(storage.NewRetryService(service, 1, 0)))
+	objects, err = decorated.ListMatch(baseURL, "*.json")
+	assert.Nil(t, err)
+	assert.EqualValues(t, []string{"config.json", "meta.json"}, names(objects))
+
+	objects, err = storage.ListMatch(storage.NewMemoryServiceWithLimit(0), "mem:///list_match", "*.json")
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(objects))
+}
+
+func TestListModifiedSince(t *testing.T) {
+	service := storage.NewService()
+	var baseDir = path.Join(os.TempDir(), "storage_list_modified_since_test")
+	defer os.RemoveAll(baseDir)
+	var cutoff = time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	var modTimes = map[string]time.Time{
+		"old.txt":    cutoff.Add(-time.Hour),
+		"cutoff.txt": cutoff,
+		"new.txt":    cutoff.Add(time.Second),
+		"newer.txt":  cutoff.Add(24 * time.Hour),
+	}
+	for name, modTime := range modTimes {
+		var filename = path.Join(baseDir, name)
+		assert.Nil(t, service.Upload(toolbox.FileSchema+filename, strings.NewReader("abc")))
+		assert.Nil(t, os.Chtimes(filename, modTime, modTime))
+	}
+	var names = func(objects []storage.Object) []string {
+		var result = make([]string, 0)
+		for _, object := range objects {
+			_, name := path.Split(object.URL())
+			result = append(result, name)
+		}
+		sort.Strings(result)
+		return result
+	}
+	objects, err := storage.ListModifiedSince(service, toolbox.FileSchema+baseDir, cutoff)
+	assert.Nil(t, err)
+	assert.EqualValues(t, []string{"new.txt", "newer.txt"}, names(objects))
+
+	objects, err = storage.ListModifiedSince(service, toolbox.FileSchema+baseDir, cutoff.Add(48*time.Hour))
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(objects))
+
+	{ //memory storage sets modification time on upload
+		memService := storage.NewMemoryServiceWithLimit(0)
+		assert.Nil(t, memService.Upload("mem:///modified_since_test/old.txt", strings.NewReader("abc")))
+		time.Sleep(10 * time.Millisecond)
+		var since = time.Now()
+		time.Sleep(10 * time.Millisecond)
+		assert.Nil(t, memService.Upload("mem:///modified_since_test/new.txt", strings.NewReader("abc")))
+		objects, err := storage.ListModifiedSince(memService, "mem:///modified_since_test", since)
+		assert.Nil(t, err)
+		assert.EqualValues(t, []string{"new.txt"}, names(objects))
+	}
+
+	_, err = storage.ListModifiedSince(service, toolbox.FileSchema+path.Join(baseDir, "missing"), cutoff)
+	assert.NotNil(t, err)
+}
+
+func TestStorageService_DeleteAll(t *testing.T) {
+	service := storage.NewService()
+	var baseDir = path.Join(os.TempDir(), "storage_delete_all_test")
+	defer os.RemoveAll(baseDir)
+	var URLs = []string{
+		"mem:///delete_all_test/file1.txt",
+		"mem:///delete_all_test/file2.txt",
+		toolbox.FileSchema + path.Join(baseDir, "file1.txt"),
+		toolbox.FileSchema + path.Join(baseDir, "file2.txt"),
+	}
+	var objects = make([]storage.Object, 0)
+	for _, URL := range URLs {
+		err := service.Upload(URL, strings.NewReader("abc"))
+		assert.Nil(t, err)
+		object, err := service.StorageObject(URL)
+		if assert.Nil(t, err) {
+			objects = append(objects, object)
+		}
+	}
+	err := service.DeleteAll(objects[:2])
+	assert.Nil(t, err)
+
+	err = service.DeleteAll(objects)
+	if assert.NotNil(t, err) {
+		assert.True(t, strings.Contains(err.Error(), "2 object(s)"))
+		assert.True(t, strings.Contains(err.Error(), URLs[0]))
+		assert.True(t, strings.Contains(err.Error(), URLs[1]))
+		assert.False(t, strings.Contains(err.Error(), URLs[2]))
+	}
+	for _, URL := range URLs {
+		exists, err := service.Exists(URL)
+		assert.Nil(t, err)
+		assert.False(t, exists, URL)
+	}
+}
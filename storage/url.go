@@ -0,0 +1,54 @@
+package storage
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/viant/toolbox"
+)
+
+//NormalizeURL returns canonical form of supplied URL: scheme is lower cased, for file and mem schemes duplicate slashes are collapsed and . and .. path segments
+//are resolved (other schemes keep path as is, since i.e. a//b and a/../b are distinct object store keys), path without scheme is converted to absolute file URL,
+//scheme:/path form is expanded to scheme:///path, trailing slash, query and fragment are preserved
+func NormalizeURL(URL string) (string, error) {
+	if URL == "" {
+		return "", fmt.Errorf("URL was empty")
+	}
+	parsedURL, err := url.Parse(URL)
+	if err != nil {
+		return "", fmt.Errorf("failed to normalize %v: %v", URL, err)
+	}
+	if parsedURL.Scheme == "" {
+		location, err := filepath.Abs(URL)
+		if err != nil {
+			return "", fmt.Errorf("failed to normalize %v: %v", URL, err)
+		}
+		URL = toolbox.FileSchema + filepath.ToSlash(location)
+	}
+	var schemaPosition = strings.Index(URL, ":")
+	var scheme = strings.ToLower(URL[:schemaPosition])
+	var remainder = URL[schemaPosition+1:]
+	if strings.HasPrefix(remainder, "//") {
+		remainder = remainder[2:]
+	}
+	var suffix = ""
+	if index := strings.IndexAny(remainder, "?#"); index != -1 {
+		suffix = remainder[index:]
+		remainder = remainder[:index]
+	}
+	var host, URLPath = remainder, ""
+	if index := strings.Index(remainder, "/"); index != -1 {
+		host, URLPath = remainder[:index], remainder[index:]
+	}
+	if URLPath != "" && (scheme == "file" || scheme == MemoryProviderScheme) {
+		var hasTrailingSlash = strings.HasSuffix(URLPath, "/")
+		URLPath = path.Clean(URLPath)
+		if hasTrailingSlash && URLPath != "/" {
+			URLPath += "/"
+		}
+	}
+	return scheme + "://" + host + URLPath + suffix, nil
+}
@@ -0,0 +1,67 @@
+package storage_test
+
+import (
+	"bytes"
+	"os"
+	"path"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/toolbox"
+	"github.com/viant/toolbox/storage"
+)
+
+func TestNormalizeURL(t *testing.T) {
+	workingDirectory, err := os.Getwd()
+	if !assert.Nil(t, err) {
+		return
+	}
+	var useCases = []struct {
+		description string
+		URL         string
+		expected    string
+	}{
+		{"canonical URL", "file:///a/b", "file:///a/b"},
+		{"scheme case", "FILE:///a/b", "file:///a/b"},
+		{"mixed scheme case", "S3://bucket/folder/key", "s3://bucket/folder/key"},
+		{"duplicate slashes", "file:///a//b///c", "file:///a/b/c"},
+		{"current directory segments", "file:///a/./b/.", "file:///a/b"},
+		{"parent directory segments", "file:///a/b/../c", "file:///a/c"},
+		{"parent directory above root", "file:///../a", "file:///a"},
+		{"trailing slash", "mem:///a//b/./", "mem:///a/b/"},
+		{"root path", "file:///", "file:///"},
+		{"host without path", "gs://bucket", "gs://bucket"},
+		{"query and fragment", "mem://Host//p/../q?x=/a/../b#f", "mem://Host/q?x=/a/../b#f"},
+		{"object store key", "S3://bucket/a//b/../c", "s3://bucket/a//b/../c"},
+		{"http path", "http://Host:8080//p/../q", "http://Host:8080//p/../q"},
+		{"bare absolute path", "/a//b/../c", "file:///a/c"},
+		{"single slash form", "file:/a/b", "file:///a/b"},
+		{"bare relative path", "test/../test/dir", toolbox.FileSchema + filepath.ToSlash(path.Join(workingDirectory, "test/dir"))},
+	}
+	for _, useCase := range useCases {
+		actual, err := storage.NormalizeURL(useCase.URL)
+		if assert.Nil(t, err, useCase.description) {
+			assert.Equal(t, useCase.expected, actual, useCase.description)
+		}
+	}
+	_, err = storage.NormalizeURL("")
+	assert.NotNil(t, err)
+	_, err = storage.NormalizeURL("http://%zz/a")
+	assert.NotNil(t, err)
+}
+
+func TestStorageService_NormalizedURL(t *testing.T) {
+	service := storage.NewService()
+	err := service.Upload("MEM:///normalize_test//a/./b.txt", bytes.NewReader([]byte("abc")))
+	assert.Nil(t, err)
+	for _, URL := range []string{"mem:///normalize_test/a/b.txt", "mem:///normalize_test/a/x/../b.txt", "Mem:///normalize_test/../normalize_test/a//b.txt"} {
+		exists, err := service.Exists(URL)
+		assert.Nil(t, err, URL)
+		assert.True(t, exists, URL)
+		object, err := service.StorageObject(URL)
+		if assert.Nil(t, err, URL) {
+			assert.Equal(t, "mem:///normalize_test/a/b.txt", object.URL(), URL)
+		}
+	}
+}
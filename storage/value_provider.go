@@ -0,0 +1,269 @@
+package storage
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"github.com/viant/toolbox"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+//variableProvider resolves variable name passed as the first argument with dictionary stored in the context or environment variables
+type variableProvider struct{}
+
+func (p variableProvider) Get(context toolbox.Context, arguments ...interface{}) (interface{}, error) {
+	var name = toolbox.AsString(arguments[0])
+	var dictionary toolbox.Dictionary
+	if context != nil {
+		context.GetInto((*toolbox.MapDictionary)(nil), &dictionary)
+	}
+	if dictionary != nil && dictionary.Exists(name) {
+		return dictionary.Get(name)
+	}
+	if value, found := os.LookupEnv(name); found {
+		return value, nil
+	}
+	return nil, fmt.Errorf("variable %v is not defined", name)
+}
+
+type templatedStorageProvider struct {
+	service   Service
+	variables toolbox.ValueProviderRegistry
+}
+
+func (p *templatedStorageProvider) Get(context toolbox.Context, arguments ...interface{}) (interface{}, error) {
+	if len(arguments) == 0 {
+		return nil, fmt.Errorf("expected URL argument but had 0")
+	}
+	URL, err := toolbox.ExpandText(toolbox.AsString(arguments[0]), p.variables, context)
+	if err != nil {
+		return nil, err
+	}
+	object, err := p.service.StorageObject(URL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lookup %v: %v", URL, err)
+	}
+	reader, err := p.service.Download(object)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %v: %v", URL, err)
+	}
+	if closer, ok := reader.(io.Closer); ok {
+		defer closer.Close()
+	}
+	content, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	return string(content), nil
+}
+
+//NewTemplatedStorageProvider returns a provider that returns content of storage object as string, URL argument can use ${VAR} references
+//resolved from *toolbox.MapDictionary stored in the context or environment variables, references are expanded with toolbox.ExpandText.
+func NewTemplatedStorageProvider(service Service) toolbox.ValueProvider {
+	var variables = toolbox.NewValueProviderRegistry()
+	variables.Register("env", variableProvider{})
+	return &templatedStorageProvider{service: service, variables: variables}
+}
+
+type listDiffProvider struct {
+	service Service
+}
+
+//list returns content objects indexed by path relative to base URL, folders are traversed recursively
+func (p *listDiffProvider) list(baseURL, URL string, result map[string]Object) error {
+	objects, err := p.service.List(URL)
+	if err != nil {
+		return fmt.Errorf("failed to list %v: %v", URL, err)
+	}
+	var basePath = urlPath(baseURL)
+	var listPath = urlPath(URL)
+	for _, object := range objects {
+		var objectPath = urlPath(object.URL())
+		if object.IsFolder() {
+			if objectPath == listPath {
+				continue
+			}
+			if err = p.list(baseURL, object.URL(), result); err != nil {
+				return err
+			}
+			continue
+		}
+		var relativePath = strings.TrimPrefix(strings.TrimPrefix(objectPath, basePath), "/")
+		result[relativePath] = object
+	}
+	return nil
+}
+
+func (p *listDiffProvider) digest(object Object) (string, error) {
+	reader, err := p.service.Download(object)
+	if err != nil {
+		return "", fmt.Errorf("failed to download %v: %v", object.URL(), err)
+	}
+	defer closeReader(reader)
+	var hash = md5.New()
+	if _, err = io.Copy(hash, reader); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func (p *listDiffProvider) isChanged(source, target Object) (bool, error) {
+	if source.FileInfo() != nil && target.FileInfo() != nil && source.FileInfo().Size() != target.FileInfo().Size() {
+		return true, nil
+	}
+	sourceDigest, err := p.digest(source)
+	if err != nil {
+		return false, err
+	}
+	targetDigest, err := p.digest(target)
+	if err != nil {
+		return false, err
+	}
+	return sourceDigest != targetDigest, nil
+}
+
+func (p *listDiffProvider) Get(context toolbox.Context, arguments ...interface{}) (interface{}, error) {
+	if len(arguments) < 2 {
+		return nil, fmt.Errorf("expected at least 2 arguments (urlA, urlB) but had: %v", len(arguments))
+	}
+	var mode = "onlyA"
+	if len(arguments) > 2 {
+		mode = toolbox.AsString(arguments[2])
+	}
+	var sourceURL, targetURL = toolbox.AsString(arguments[0]), toolbox.AsString(arguments[1])
+	var sourceObjects, targetObjects = make(map[string]Object), make(map[string]Object)
+	if err := p.list(sourceURL, sourceURL, sourceObjects); err != nil {
+		return nil, err
+	}
+	if err := p.list(targetURL, targetURL, targetObjects); err != nil {
+		return nil, err
+	}
+	var result = make([]string, 0)
+	switch mode {
+	case "onlyA", "onlyB":
+		var objects, others = sourceObjects, targetObjects
+		if mode == "onlyB" {
+			objects, others = targetObjects, sourceObjects
+		}
+		for relativePath, object := range objects {
+			if _, has := others[relativePath]; !has {
+				result = append(result, object.URL())
+			}
+		}
+	case "both", "changed":
+		for relativePath, object := range sourceObjects {
+			targetObject, has := targetObjects[relativePath]
+			if !has {
+				continue
+			}
+			if mode == "changed" {
+				changed, err := p.isChanged(object, targetObject)
+				if err != nil {
+					return nil, err
+				}
+				if !changed {
+					continue
+				}
+			}
+			result = append(result, object.URL())
+		}
+	default:
+		return nil, fmt.Errorf("unsupported mode: %v", mode)
+	}
+	sort.Strings(result)
+	return result, nil
+}
+
+//NewListDiffProvider returns a provider that compares listings of two URLs by relative path, it returns sorted URLs present under urlA but not under urlB,
+//optional mode argument: onlyA (default), onlyB, both (present in both) or changed (present in both with different size or checksum) controls returned set.
+func NewListDiffProvider(service Service) toolbox.ValueProvider {
+	return &listDiffProvider{service: service}
+}
+
+type fileContentProvider struct {
+	mutex    *sync.Mutex
+	services map[string]Service
+}
+
+//service returns cached service for URL scheme, it creates one on first use
+func (p *fileContentProvider) service(URL string) (Service, error) {
+	parsedURL, err := url.Parse(URL)
+	if err != nil {
+		return nil, err
+	}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if service, ok := p.services[parsedURL.Scheme]; ok {
+		return service, nil
+	}
+	service, err := NewServiceForURL(URL, "")
+	if err != nil {
+		return nil, err
+	}
+	p.services[parsedURL.Scheme] = service
+	return service, nil
+}
+
+func (p *fileContentProvider) Get(context toolbox.Context, arguments ...interface{}) (interface{}, error) {
+	if len(arguments) == 0 {
+		return nil, fmt.Errorf("expected path argument but had 0")
+	}
+	var mode = "string"
+	if len(arguments) > 1 {
+		mode = toolbox.AsString(arguments[1])
+	}
+	if mode != "string" && mode != "bytes" {
+		return nil, fmt.Errorf("unsupported mode: %v, expected string or bytes", mode)
+	}
+	var URL = toolbox.AsString(arguments[0])
+	if !strings.Contains(URL, "://") {
+		location, err := filepath.Abs(URL)
+		if err != nil {
+			return nil, err
+		}
+		URL = toolbox.FileSchema + location
+	}
+	service, err := p.service(URL)
+	if err != nil {
+		return nil, err
+	}
+	object, err := service.StorageObject(URL)
+	if err != nil {
+		return nil, fmt.Errorf("resource not found: %v, %v", URL, err)
+	}
+	if object == nil {
+		return nil, fmt.Errorf("resource not found: %v", URL)
+	}
+	if object.IsFolder() {
+		return nil, fmt.Errorf("failed to read %v: resource is a folder", URL)
+	}
+	reader, err := service.Download(object)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %v: %v", URL, err)
+	}
+	defer closeReader(reader)
+	content, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %v: %v", URL, err)
+	}
+	if mode == "bytes" {
+		return content, nil
+	}
+	return string(content), nil
+}
+
+//NewFileContentProvider returns a provider that returns content of resource for supplied path or URL, optional second argument
+//selects result type: string (default) or bytes. Path without scheme is treated as local file.
+func NewFileContentProvider() toolbox.ValueProvider {
+	return &fileContentProvider{
+		mutex:    &sync.Mutex{},
+		services: make(map[string]Service),
+	}
+}
@@ -0,0 +1,129 @@
+package storage_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/toolbox"
+	"github.com/viant/toolbox/storage"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestNewTemplatedStorageProvider(t *testing.T) {
+	service := storage.NewMemoryService()
+	err := service.Upload("mem:///templated/prod/config.json", strings.NewReader(`{"env":"prod"}`))
+	assert.Nil(t, err)
+	os.Setenv("TEMPLATED_BUCKET", "templated")
+	defer os.Unsetenv("TEMPLATED_BUCKET")
+
+	provider := storage.NewTemplatedStorageProvider(service)
+	{
+		var dictionary toolbox.MapDictionary = map[string]interface{}{"ENV": "prod"}
+		context := toolbox.NewContext()
+		context.Put(&dictionary, &dictionary)
+		value, err := provider.Get(context, "mem:///${TEMPLATED_BUCKET}/${ENV}/config.json")
+		assert.Nil(t, err)
+		assert.Equal(t, `{"env":"prod"}`, value)
+	}
+	{
+		_, err := provider.Get(nil, "mem:///${TEMPLATED_BUCKET}/${ENV}/config.json")
+		assert.NotNil(t, err)
+	}
+	{
+		_, err := provider.Get(nil, "mem:///${TEMPLATED_BUCKET/config.json")
+		assert.NotNil(t, err)
+	}
+	{
+		_, err := provider.Get(nil, "mem:///${TEMPLATED_BUCKET}/missing.json")
+		assert.NotNil(t, err)
+	}
+	{ //substituted value is not expanded again
+		os.Setenv("TEMPLATED_LOOP", "${TEMPLATED_LOOP}")
+		defer os.Unsetenv("TEMPLATED_LOOP")
+		_, err := provider.Get(nil, "mem:///${TEMPLATED_LOOP}/config.json")
+		if assert.NotNil(t, err) {
+			assert.Contains(t, err.Error(), "mem:///${TEMPLATED_LOOP}/config.json")
+		}
+	}
+}
+
+func TestNewListDiffProvider(t *testing.T) {
+	service := storage.NewMemoryService()
+	for URL, content := range map[string]string{
+		"mem:///listdiff/a/file1.txt":     "abc",
+		"mem:///listdiff/a/file2.txt":     "xyz",
+		"mem:///listdiff/a/sub/file3.txt": "123",
+		"mem:///listdiff/a/sub/file4.txt": "456",
+		"mem:///listdiff/b/file1.txt":     "abc",
+		"mem:///listdiff/b/sub/file3.txt": "321",
+		"mem:///listdiff/b/sub/file4.txt": "4567",
+		"mem:///listdiff/b/file5.txt":     "xyz",
+	} {
+		err := service.Upload(URL, strings.NewReader(content))
+		assert.Nil(t, err)
+	}
+	provider := storage.NewListDiffProvider(service)
+
+	var useCases = []struct {
+		arguments []interface{}
+		expected  []string
+	}{
+		{[]interface{}{"mem:///listdiff/a", "mem:///listdiff/b"}, []string{"mem:///listdiff/a/file2.txt"}},
+		{[]interface{}{"mem:///listdiff/a", "mem:///listdiff/b", "onlyB"}, []string{"mem:///listdiff/b/file5.txt"}},
+		{[]interface{}{"mem:///listdiff/a", "mem:///listdiff/b", "both"}, []string{"mem:///listdiff/a/file1.txt", "mem:///listdiff/a/sub/file3.txt", "mem:///listdiff/a/sub/file4.txt"}},
+		{[]interface{}{"mem:///listdiff/a", "mem:///listdiff/b", "changed"}, []string{"mem:///listdiff/a/sub/file3.txt", "mem:///listdiff/a/sub/file4.txt"}},
+	}
+	for _, useCase := range useCases {
+		value, err := provider.Get(nil, useCase.arguments...)
+		if assert.Nil(t, err) {
+			assert.EqualValues(t, useCase.expected, value)
+		}
+	}
+	{
+		_, err := provider.Get(nil, "mem:///listdiff/a", "mem:///listdiff/b", "abc")
+		assert.NotNil(t, err)
+	}
+	{
+		_, err := provider.Get(nil, "mem:///listdiff/a", "mem:///listdiff/c/sub/file")
+		assert.NotNil(t, err)
+	}
+}
+
+func TestNewFileContentProvider(t *testing.T) {
+	file, err := ioutil.TempFile("", "file_content_provider")
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer os.Remove(file.Name())
+	var payload = strings.Repeat("file content\n", 100)
+	_, err = file.WriteString(payload)
+	assert.Nil(t, err)
+	file.Close()
+
+	provider := storage.NewFileContentProvider()
+	{
+		value, err := provider.Get(nil, toolbox.FileSchema+file.Name())
+		assert.Nil(t, err)
+		assert.Equal(t, payload, value)
+	}
+	{
+		value, err := provider.Get(nil, file.Name(), "bytes")
+		assert.Nil(t, err)
+		assert.Equal(t, []byte(payload), value)
+	}
+	{
+		_, err := provider.Get(nil, file.Name()+".missing")
+		if assert.NotNil(t, err) {
+			assert.Contains(t, err.Error(), "not found")
+		}
+	}
+	{
+		_, err := provider.Get(nil, file.Name(), "json")
+		assert.NotNil(t, err)
+	}
+	{
+		_, err := provider.Get(nil)
+		assert.NotNil(t, err)
+	}
+}
@@ -0,0 +1,76 @@
+package toolbox
+
+import (
+	"fmt"
+	"strings"
+)
+
+type stringOpProvider struct{}
+
+//clampIndex returns index within 0..length range
+func clampIndex(index, length int) int {
+	if index < 0 {
+		return 0
+	}
+	if index > length {
+		return length
+	}
+	return index
+}
+
+func (p stringOpProvider) Get(context Context, arguments ...interface{}) (interface{}, error) {
+	if len(arguments) < 2 {
+		return nil, fmt.Errorf("expected at least 2 arguments (operation, text) but had %v", len(arguments))
+	}
+	var operation = AsString(arguments[0])
+	var text = AsString(arguments[1])
+	var operands = arguments[2:]
+	switch operation {
+	case "trim":
+		if len(operands) == 0 {
+			return strings.TrimSpace(text), nil
+		}
+		return strings.Trim(text, AsString(operands[0])), nil
+	case "replace":
+		if len(operands) < 2 {
+			return nil, fmt.Errorf("expected replace old and new arguments but had %v", len(operands))
+		}
+		var count = -1
+		if len(operands) > 2 {
+			var err error
+			if count, err = ToInt(operands[2]); err != nil {
+				return nil, fmt.Errorf("invalid replace count: %v", err)
+			}
+		}
+		return strings.Replace(text, AsString(operands[0]), AsString(operands[1]), count), nil
+	case "substr":
+		if len(operands) == 0 {
+			return nil, fmt.Errorf("expected substr start argument")
+		}
+		var runes = []rune(text)
+		start, err := ToInt(operands[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid substr start: %v", err)
+		}
+		var end = len(runes)
+		if len(operands) > 1 {
+			if end, err = ToInt(operands[1]); err != nil {
+				return nil, fmt.Errorf("invalid substr end: %v", err)
+			}
+		}
+		start, end = clampIndex(start, len(runes)), clampIndex(end, len(runes))
+		if end < start {
+			return "", nil
+		}
+		return string(runes[start:end]), nil
+	}
+	return nil, fmt.Errorf("unsupported string operation: %v, expected trim, replace or substr", operation)
+}
+
+//NewStringOpProvider returns a provider that applies operation (first argument) to text (second argument):
+//trim [cutset] trims whitespace or cutset characters, replace old new [count] replaces occurrences (all if count is not specified or negative),
+//substr start [end] returns characters (runes) between start and end, out of range indices are clamped
+func NewStringOpProvider() ValueProvider {
+	var result ValueProvider = &stringOpProvider{}
+	return result
+}
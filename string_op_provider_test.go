@@ -0,0 +1,46 @@
+package toolbox_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/toolbox"
+)
+
+func TestNewStringOpProvider(t *testing.T) {
+	provider := toolbox.NewStringOpProvider()
+	var useCases = []struct {
+		description string
+		arguments   []interface{}
+		expected    string
+	}{
+		{"trim whitespace", []interface{}{"trim", " \t abc \n"}, "abc"},
+		{"trim cutset", []interface{}{"trim", "--abc-+", "-+"}, "abc"},
+		{"replace all", []interface{}{"replace", "a.b.c", ".", "/"}, "a/b/c"},
+		{"replace count", []interface{}{"replace", "a.b.c", ".", "/", 1}, "a/b.c"},
+		{"replace text count", []interface{}{"replace", "a.b.c", ".", "", "-1"}, "abc"},
+		{"substr", []interface{}{"substr", "abcdef", 1, 3}, "bc"},
+		{"substr to end", []interface{}{"substr", "abcdef", 2}, "cdef"},
+		{"substr unicode", []interface{}{"substr", "żółw ąę", 1, 4}, "ółw"},
+		{"substr clamped end", []interface{}{"substr", "abc", 1, 10}, "bc"},
+		{"substr clamped start", []interface{}{"substr", "abc", -5, 2}, "ab"},
+		{"substr start beyond length", []interface{}{"substr", "abc", 7, 9}, ""},
+		{"substr end before start", []interface{}{"substr", "abcdef", 4, 2}, ""},
+	}
+	for _, useCase := range useCases {
+		value, err := provider.Get(nil, useCase.arguments...)
+		assert.Nil(t, err, useCase.description)
+		assert.Equal(t, useCase.expected, value, useCase.description)
+	}
+	for _, arguments := range [][]interface{}{
+		{"trim"},
+		{"pad", "abc"},
+		{"replace", "abc", "a"},
+		{"replace", "abc", "a", "b", "x"},
+		{"substr", "abc"},
+		{"substr", "abc", "x"},
+	} {
+		_, err := provider.Get(nil, arguments...)
+		assert.NotNil(t, err, arguments)
+	}
+}
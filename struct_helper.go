@@ -1,8 +1,10 @@
 package toolbox
 
 import (
+	"fmt"
 	"reflect"
 	"strings"
+	"time"
 )
 
 var columnMapping = []string{"column", "dateLayout", "dateFormat", "autoincrement", "primaryKey", "sequence", "valueMap"}
@@ -67,3 +69,189 @@ func BuildTagMapping(structTemplatePointer interface{}, mappedKeyTag string, res
 func NewFieldSettingByKey(aStruct interface{}, key string) map[string](map[string]string) {
 	return BuildTagMapping(aStruct, key, "transient", true, true, columnMapping)
 }
+
+var timeType = reflect.TypeOf(time.Time{})
+
+func structFieldKey(field reflect.StructField, tagName string) string {
+	if tagName == "" {
+		return field.Name
+	}
+	var key = field.Tag.Get(tagName)
+	if index := strings.Index(key, ","); index != -1 {
+		key = key[:index]
+	}
+	if key == "" {
+		return field.Name
+	}
+	return key
+}
+
+func structFieldValue(value reflect.Value, tagName string) interface{} {
+	switch value.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if value.IsNil() {
+			return nil
+		}
+		return structFieldValue(value.Elem(), tagName)
+	case reflect.Struct:
+		if value.Type() == timeType {
+			return value.Interface()
+		}
+		var result = make(map[string]interface{})
+		structToMap(value, tagName, result)
+		return result
+	case reflect.Slice, reflect.Array:
+		if value.Kind() == reflect.Slice && value.IsNil() {
+			return value.Interface()
+		}
+		var elementType = value.Type().Elem()
+		for elementType.Kind() == reflect.Ptr {
+			elementType = elementType.Elem()
+		}
+		if elementType.Kind() != reflect.Struct || elementType == timeType {
+			return value.Interface()
+		}
+		var result = make([]interface{}, value.Len())
+		for i := 0; i < value.Len(); i++ {
+			result[i] = structFieldValue(value.Index(i), tagName)
+		}
+		return result
+	}
+	return value.Interface()
+}
+
+func structToMap(structValue reflect.Value, tagName string, result map[string]interface{}) {
+	structType := structValue.Type()
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		var tagValue = ""
+		if tagName != "" {
+			tagValue = field.Tag.Get(tagName)
+		}
+		if tagValue == "-" {
+			continue
+		}
+		if field.PkgPath != "" {
+			//skip private fields
+			continue
+		}
+		fieldValue := structValue.Field(i)
+		if field.Anonymous && (tagValue == "" || strings.HasPrefix(tagValue, ",")) {
+			var embedded = fieldValue
+			if embedded.Kind() == reflect.Ptr {
+				if embedded.IsNil() {
+					continue
+				}
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct && embedded.Type() != timeType {
+				//embedded struct fields are promoted into parent map
+				structToMap(embedded, tagName, result)
+				continue
+			}
+		}
+		result[structFieldKey(field, tagName)] = structFieldValue(fieldValue, tagName)
+	}
+}
+
+//StructToMap converts exported fields of passed in struct or struct pointer into a map keyed by tagName tag value or field name if tag is missing,
+//fields tagged with "-" are skipped, nested structs are converted into nested maps and embedded struct fields are promoted.
+func StructToMap(value interface{}, tagName string) (map[string]interface{}, error) {
+	structValue := reflect.ValueOf(value)
+	for structValue.Kind() == reflect.Ptr {
+		if structValue.IsNil() {
+			return nil, fmt.Errorf("unable to convert nil %T to map", value)
+		}
+		structValue = structValue.Elem()
+	}
+	if structValue.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("unable to convert %T to map, expected struct", value)
+	}
+	var result = make(map[string]interface{})
+	structToMap(structValue, tagName, result)
+	return result, nil
+}
+
+func lookupMapValue(source map[string]interface{}, key string) (interface{}, bool) {
+	if value, ok := source[key]; ok {
+		return value, true
+	}
+	for candidate, value := range source {
+		if strings.EqualFold(candidate, key) {
+			return value, true
+		}
+	}
+	return nil, false
+}
+
+func mapToStruct(converter *Converter, source map[string]interface{}, structValue reflect.Value, tagName, path string) error {
+	structType := structValue.Type()
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		if field.PkgPath != "" {
+			//skip private fields
+			continue
+		}
+		var tagValue = ""
+		if tagName != "" {
+			tagValue = field.Tag.Get(tagName)
+		}
+		if tagValue == "-" {
+			continue
+		}
+		fieldValue := structValue.Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct && (tagValue == "" || strings.HasPrefix(tagValue, ",")) {
+			//embedded struct fields are promoted from parent map
+			if err := mapToStruct(converter, source, fieldValue, tagName, path); err != nil {
+				return err
+			}
+			continue
+		}
+		var fieldPath = path + field.Name
+		value, ok := lookupMapValue(source, structFieldKey(field, tagName))
+		if !ok {
+			continue
+		}
+		if value == nil {
+			fieldValue.Set(reflect.Zero(field.Type))
+			continue
+		}
+		if nested, ok := value.(map[string]interface{}); ok {
+			var nestedStruct = fieldValue
+			if nestedStruct.Kind() == reflect.Ptr && nestedStruct.Type().Elem().Kind() == reflect.Struct {
+				if nestedStruct.IsNil() {
+					nestedStruct.Set(reflect.New(nestedStruct.Type().Elem()))
+				}
+				nestedStruct = nestedStruct.Elem()
+			}
+			if nestedStruct.Kind() == reflect.Struct && nestedStruct.Type() != timeType {
+				if err := mapToStruct(converter, nested, nestedStruct, tagName, fieldPath+"."); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		if reflect.TypeOf(value).AssignableTo(field.Type) {
+			fieldValue.Set(reflect.ValueOf(value))
+			continue
+		}
+		var convertedPointer = reflect.New(field.Type)
+		if err := converter.AssignConverted(convertedPointer.Interface(), value); err != nil {
+			return fmt.Errorf("failed to assign field %v (%v) with %T(%v): %v", fieldPath, field.Type, value, value, err)
+		}
+		fieldValue.Set(convertedPointer.Elem())
+	}
+	return nil
+}
+
+//MapToStruct populates exported fields of passed in struct pointer from source map, key is matched with tagName tag value or field name (case insensitive fallback),
+//values are coerced with converter, nested maps populate nested struct fields and fields without matching keys are left untouched.
+func MapToStruct(source map[string]interface{}, target interface{}, tagName string) error {
+	targetValue := reflect.ValueOf(target)
+	if targetValue.Kind() != reflect.Ptr || targetValue.IsNil() || targetValue.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("unable to populate %T from map, expected non nil struct pointer", target)
+	}
+	converter := NewColumnConverter(DefaultDateLayout)
+	converter.MappedKeyTag = tagName
+	return mapToStruct(converter, source, targetValue.Elem(), tagName, "")
+}
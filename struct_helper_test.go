@@ -78,3 +78,153 @@ func TestBuildTagMapping(t *testing.T) {
 	}
 
 }
+
+type structToMapAddress struct {
+	City string `json:"city"`
+	Zip  string `json:"zip,omitempty"`
+}
+
+type StructToMapBase struct {
+	ID      int `json:"id"`
+	Created time.Time
+}
+
+type structToMapUser struct {
+	StructToMapBase
+	Name      string `json:"name"`
+	Password  string `json:"-"`
+	Address   structToMapAddress
+	Billing   *structToMapAddress `json:"billing"`
+	Shipping  *structToMapAddress `json:"shipping"`
+	Tags      []string            `json:"tags"`
+	Locations []*structToMapAddress
+	internal  string
+}
+
+func TestStructToMap(t *testing.T) {
+	var created = time.Unix(1500000000, 0)
+	var user = &structToMapUser{
+		StructToMapBase: StructToMapBase{ID: 7, Created: created},
+		Name:            "Bob",
+		Password:        "secret",
+		Address:         structToMapAddress{City: "Los Angeles", Zip: "90001"},
+		Billing:         &structToMapAddress{City: "Austin"},
+		Tags:            []string{"a", "b"},
+		Locations:       []*structToMapAddress{{City: "Dallas"}},
+		internal:        "hidden",
+	}
+
+	{ //json tag keys
+		result, err := toolbox.StructToMap(user, "json")
+		assert.Nil(t, err)
+		assert.EqualValues(t, map[string]interface{}{
+			"id":       7,
+			"Created":  created,
+			"name":     "Bob",
+			"Address":  map[string]interface{}{"city": "Los Angeles", "zip": "90001"},
+			"billing":  map[string]interface{}{"city": "Austin", "zip": ""},
+			"shipping": nil,
+			"tags":     []string{"a", "b"},
+			"Locations": []interface{}{
+				map[string]interface{}{"city": "Dallas", "zip": ""},
+			},
+		}, result)
+	}
+
+	{ //field name keys
+		result, err := toolbox.StructToMap(*user, "")
+		assert.Nil(t, err)
+		assert.Equal(t, "secret", result["Password"])
+		assert.Equal(t, map[string]interface{}{"City": "Los Angeles", "Zip": "90001"}, result["Address"])
+		_, has := result["internal"]
+		assert.False(t, has)
+		assert.Equal(t, 7, result["ID"])
+	}
+
+	{ //invalid input
+		_, err := toolbox.StructToMap(map[string]interface{}{}, "json")
+		assert.NotNil(t, err)
+		var nilUser *structToMapUser
+		_, err = toolbox.StructToMap(nilUser, "json")
+		assert.NotNil(t, err)
+	}
+}
+
+type mapToStructTarget struct {
+	StructToMapBase
+	Name      string `json:"name"`
+	Age       int    `json:"age"`
+	Score     float64
+	Active    bool                `json:"active"`
+	Secret    string              `json:"-"`
+	Address   structToMapAddress  `json:"address"`
+	Billing   *structToMapAddress `json:"billing"`
+	Tags      []string            `json:"tags"`
+	Limits    mapToStructLimits   `json:"limits"`
+	Untouched string
+}
+
+type mapToStructLimits struct {
+	Max int `json:"max"`
+}
+
+func TestMapToStruct(t *testing.T) {
+	var target = &mapToStructTarget{Untouched: "keep", Secret: "keep"}
+	err := toolbox.MapToStruct(map[string]interface{}{
+		"id":      "12",
+		"name":    "Bob",
+		"age":     "33",
+		"score":   "4.5",
+		"active":  "true",
+		"Secret":  "overwritten",
+		"address": map[string]interface{}{"city": "Austin", "zip": 73301},
+		"billing": map[string]interface{}{"City": "Dallas"},
+		"tags":    []interface{}{"a", 1},
+	}, target, "json")
+	if assert.Nil(t, err) {
+		assert.Equal(t, 12, target.ID)
+		assert.Equal(t, "Bob", target.Name)
+		assert.Equal(t, 33, target.Age)
+		assert.Equal(t, 4.5, target.Score)
+		assert.True(t, target.Active)
+		assert.Equal(t, "keep", target.Secret)
+		assert.Equal(t, "keep", target.Untouched)
+		assert.Equal(t, structToMapAddress{City: "Austin", Zip: "73301"}, target.Address)
+		if assert.NotNil(t, target.Billing) {
+			assert.Equal(t, "Dallas", target.Billing.City)
+		}
+		assert.Equal(t, []string{"a", "1"}, target.Tags)
+	}
+
+	{ //nested population keeps unmatched nested fields
+		err = toolbox.MapToStruct(map[string]interface{}{"address": map[string]interface{}{"city": "Boston"}}, target, "json")
+		assert.Nil(t, err)
+		assert.Equal(t, structToMapAddress{City: "Boston", Zip: "73301"}, target.Address)
+		assert.Equal(t, "Bob", target.Name)
+	}
+
+	{ //type mismatch names field
+		err = toolbox.MapToStruct(map[string]interface{}{"age": "thirty"}, target, "json")
+		if assert.NotNil(t, err) {
+			assert.Contains(t, err.Error(), "Age")
+		}
+		err = toolbox.MapToStruct(map[string]interface{}{"limits": map[string]interface{}{"max": "none"}}, target, "json")
+		if assert.NotNil(t, err) {
+			assert.Contains(t, err.Error(), "Limits.Max")
+		}
+	}
+
+	{ //round trip with struct to map
+		aMap, err := toolbox.StructToMap(target, "json")
+		assert.Nil(t, err)
+		var clone = &mapToStructTarget{}
+		err = toolbox.MapToStruct(aMap, clone, "json")
+		assert.Nil(t, err)
+		clone.Secret = target.Secret
+		assert.EqualValues(t, target, clone)
+	}
+
+	{ //invalid target
+		assert.NotNil(t, toolbox.MapToStruct(map[string]interface{}{}, mapToStructTarget{}, "json"))
+	}
+}
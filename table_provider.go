@@ -0,0 +1,179 @@
+package toolbox
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+type tableProvider struct{}
+
+//rows converts slice of maps or slice of slices into string cells, if no headers are provided map keys are used as sorted headers
+func (p tableProvider) rows(source interface{}, headers []string) ([]string, [][]string, error) {
+	var items = AsSlice(source)
+	var hasMaps = false
+	for _, item := range items {
+		if item != nil && IsMap(item) {
+			hasMaps = true
+			break
+		}
+	}
+	if hasMaps && len(headers) == 0 {
+		var keys = make(map[string]bool)
+		for _, item := range items {
+			if item == nil || !IsMap(item) {
+				continue
+			}
+			for key := range AsMap(item) {
+				keys[key] = true
+			}
+		}
+		for key := range keys {
+			headers = append(headers, key)
+		}
+		sort.Strings(headers)
+	}
+	var result = make([][]string, 0)
+	for i, item := range items {
+		var row = make([]string, 0)
+		switch {
+		case item == nil:
+		case IsMap(item):
+			var aMap = AsMap(item)
+			for _, header := range headers {
+				if value, ok := aMap[header]; ok && value != nil {
+					row = append(row, AsString(value))
+				} else {
+					row = append(row, "")
+				}
+			}
+		case IsSlice(item):
+			for _, value := range AsSlice(item) {
+				if value == nil {
+					row = append(row, "")
+					continue
+				}
+				row = append(row, AsString(value))
+			}
+		default:
+			return nil, nil, fmt.Errorf("expected map or slice at row %v but had %T", i, item)
+		}
+		result = append(result, row)
+	}
+	return headers, result, nil
+}
+
+func (p tableProvider) format(format string, headers []string, rows [][]string) (string, error) {
+	var columns = len(headers)
+	for _, row := range rows {
+		if len(row) > columns {
+			columns = len(row)
+		}
+	}
+	var pad = func(row []string) []string {
+		for len(row) < columns {
+			row = append(row, "")
+		}
+		return row
+	}
+	var buffer = new(bytes.Buffer)
+	switch format {
+	case "csv":
+		writer := csv.NewWriter(buffer)
+		if len(headers) > 0 {
+			writer.Write(headers)
+		}
+		for _, row := range rows {
+			writer.Write(pad(row))
+		}
+		writer.Flush()
+		return buffer.String(), writer.Error()
+	case "markdown":
+		if len(headers) == 0 {
+			headers, rows = rows[0], rows[1:]
+		}
+		var escape = func(row []string) []string {
+			var result = make([]string, len(row))
+			for i, cell := range row {
+				result[i] = strings.Replace(cell, "|", `\|`, -1)
+			}
+			return result
+		}
+		var separator = make([]string, columns)
+		for i := range separator {
+			separator[i] = "---"
+		}
+		for _, row := range append([][]string{pad(headers), separator}, rows...) {
+			buffer.WriteString("| " + strings.Join(escape(pad(row)), " | ") + " |\n")
+		}
+		return buffer.String(), nil
+	case "text", "":
+		var widths = make([]int, columns)
+		var all = rows
+		if len(headers) > 0 {
+			var underline = make([]string, len(headers))
+			for i, header := range headers {
+				underline[i] = strings.Repeat("-", utf8.RuneCountInString(header))
+			}
+			all = append([][]string{headers, underline}, rows...)
+		}
+		for _, row := range all {
+			for i, cell := range row {
+				if width := utf8.RuneCountInString(cell); width > widths[i] {
+					widths[i] = width
+				}
+			}
+		}
+		for _, row := range all {
+			var line = make([]string, columns)
+			for i, cell := range pad(row) {
+				line[i] = cell + strings.Repeat(" ", widths[i]-utf8.RuneCountInString(cell))
+			}
+			buffer.WriteString(strings.TrimRight(strings.Join(line, "  "), " ") + "\n")
+		}
+		return buffer.String(), nil
+	}
+	return "", fmt.Errorf("unsupported table format: %v", format)
+}
+
+func (p tableProvider) Get(context Context, arguments ...interface{}) (interface{}, error) {
+	if len(arguments) == 0 {
+		return nil, fmt.Errorf("expected at least one argument but had 0")
+	}
+	if arguments[0] == nil {
+		return "", nil
+	}
+	if !IsSlice(arguments[0]) {
+		return nil, fmt.Errorf("expected slice but had %T", arguments[0])
+	}
+	var headers = make([]string, 0)
+	var format = "text"
+	for _, argument := range arguments[1:] {
+		if argument != nil && IsSlice(argument) {
+			ProcessSlice(argument, func(item interface{}) bool {
+				headers = append(headers, AsString(item))
+				return true
+			})
+			continue
+		}
+		format = strings.ToLower(AsString(argument))
+	}
+	headers, rows, err := p.rows(arguments[0], headers)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return "", nil
+	}
+	return p.format(format, headers, rows)
+}
+
+//NewTableProvider returns a provider that formats slice of maps or slice of slices (first argument) as a table, optional headers slice defines columns order,
+//optional format argument: text (aligned columns, default), csv or markdown. For maps without headers sorted keys are used, markdown table without headers uses the first row as header.
+func NewTableProvider() ValueProvider {
+	var result ValueProvider = &tableProvider{}
+	return result
+}
@@ -0,0 +1,65 @@
+package toolbox_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/toolbox"
+)
+
+func TestNewTableProvider(t *testing.T) {
+	provider := toolbox.NewTableProvider()
+	var records = []map[string]interface{}{
+		{"id": 1, "name": "alpha"},
+		{"id": 20, "name": "b|c", "extra": true},
+	}
+	{
+		value, err := provider.Get(nil, records, []string{"name", "id"})
+		assert.Nil(t, err)
+		assert.Equal(t, "name   id\n"+
+			"----   --\n"+
+			"alpha  1\n"+
+			"b|c    20\n", value)
+	}
+	{
+		value, err := provider.Get(nil, records)
+		assert.Nil(t, err)
+		assert.Equal(t, "extra  id  name\n"+
+			"-----  --  ----\n"+
+			"       1   alpha\n"+
+			"true   20  b|c\n", value)
+	}
+	{
+		value, err := provider.Get(nil, records, []string{"id", "name"}, "csv")
+		assert.Nil(t, err)
+		assert.Equal(t, "id,name\n1,alpha\n20,b|c\n", value)
+	}
+	{
+		value, err := provider.Get(nil, records, []string{"id", "name"}, "markdown")
+		assert.Nil(t, err)
+		assert.Equal(t, "| id | name |\n| --- | --- |\n| 1 | alpha |\n| 20 | b\\|c |\n", value)
+	}
+	{
+		value, err := provider.Get(nil, [][]interface{}{{"a", 1}, {"bbb", 2, "x"}})
+		assert.Nil(t, err)
+		assert.Equal(t, "a    1\nbbb  2  x\n", value)
+	}
+	{
+		value, err := provider.Get(nil, [][]string{{"k", "v"}, {"a", "1"}}, "markdown")
+		assert.Nil(t, err)
+		assert.Equal(t, "| k | v |\n| --- | --- |\n| a | 1 |\n", value)
+	}
+	{
+		value, err := provider.Get(nil, []interface{}{}, "csv")
+		assert.Nil(t, err)
+		assert.Equal(t, "", value)
+	}
+	{
+		_, err := provider.Get(nil, records, "xml")
+		assert.NotNil(t, err)
+	}
+	{
+		_, err := provider.Get(nil, []interface{}{1, 2})
+		assert.NotNil(t, err)
+	}
+}
@@ -1,6 +1,7 @@
 package toolbox
 
 import (
+	"fmt"
 	"strings"
 	"time"
 )
@@ -11,49 +12,172 @@ var DateFormatKeyword = "dateFormat"
 //DateLayoutKeyword constant 'dateLayout' key
 var DateLayoutKeyword = "dateLayout"
 
+//dateFormatTokenLayout returns go layout fragment for a java date format token made of count repeated letters, ok is false for non pattern letters
+func dateFormatTokenLayout(letter byte, count int) (layout string, ok bool) {
+	switch letter {
+	case 'a':
+		return "PM", true
+	case 'd':
+		switch count {
+		case 1:
+			return "2", true
+		case 2:
+			return "02", true
+		}
+		return "_2", true
+	case 'H':
+		return "15", true
+	case 'h':
+		if count == 1 {
+			return "3", true
+		}
+		return "03", true
+	case 'm':
+		if count == 1 {
+			return "4", true
+		}
+		return "04", true
+	case 's':
+		if count == 1 {
+			return "5", true
+		}
+		return "05", true
+	case 'y':
+		if count <= 2 {
+			return "06", true
+		}
+		return "2006", true
+	case 'S':
+		//fractional second digits follow S run length, go layout supports up to nanoseconds
+		if count > 9 {
+			count = 9
+		}
+		return strings.Repeat("0", count), true
+	case 'M':
+		switch count {
+		case 1:
+			return "1", true
+		case 2:
+			return "01", true
+		case 3:
+			return "Jan", true
+		}
+		return "January", true
+	case 'z':
+		if count >= 4 {
+			return "Z0700", true
+		}
+		return "MST", true
+	case 'Z':
+		if count == 1 {
+			return "-07", true
+		}
+		return "-0700", true
+	case 'X':
+		switch count {
+		case 1:
+			return "Z07", true
+		case 2:
+			return "Z0700", true
+		}
+		return "Z07:00", true
+	case 'E':
+		if count >= 4 {
+			return "Monday", true
+		}
+		return "Mon", true
+	}
+	return "", false
+}
+
 //DateFormatToLayout converts java date format https://docs.oracle.com/javase/6/docs/api/java/text/SimpleDateFormat.html#rfc822timezone into go date layout
+//consecutive repeated pattern letters are matched as a single token, i.e. MMMM is January, not MM followed by MM
 func DateFormatToLayout(dateFormat string) string {
+	var result = make([]byte, 0, len(dateFormat)+8)
+	for i := 0; i < len(dateFormat); {
+		if strings.HasPrefix(dateFormat[i:], "zz:zz") {
+			result = append(result, "Z07:00"...)
+			i += len("zz:zz")
+			continue
+		}
+		var letter = dateFormat[i]
+		var count = 1
+		for i+count < len(dateFormat) && dateFormat[i+count] == letter {
+			count++
+		}
+		if layout, ok := dateFormatTokenLayout(letter, count); ok {
+			result = append(result, layout...)
+		} else {
+			result = append(result, dateFormat[i:i+count]...)
+		}
+		i += count
+	}
+	return string(result)
+}
 
-	dateFormat = strings.Replace(dateFormat, "a", "pm", 1)
-	dateFormat = strings.Replace(dateFormat, "aa", "PM", 1)
-
-	dateFormat = strings.Replace(dateFormat, "ddd", "_2", 1)
-	dateFormat = strings.Replace(dateFormat, "dd", "02", 1)
-	dateFormat = strings.Replace(dateFormat, "d", "2", 1)
-
-	dateFormat = strings.Replace(dateFormat, "HH", "15", 1)
-	dateFormat = strings.Replace(dateFormat, "H", "15", 1)
-
-	dateFormat = strings.Replace(dateFormat, "hh", "03", 1)
-	dateFormat = strings.Replace(dateFormat, "h", "3", 1)
-
-	dateFormat = strings.Replace(dateFormat, "mm", "04", 1)
-	dateFormat = strings.Replace(dateFormat, "m", "4", 1)
-
-	dateFormat = strings.Replace(dateFormat, "ss", "05", 1)
-	dateFormat = strings.Replace(dateFormat, "s", "5", 1)
-
-	dateFormat = strings.Replace(dateFormat, "yyyy", "2006", 1)
-	dateFormat = strings.Replace(dateFormat, "yy", "06", 1)
-	dateFormat = strings.Replace(dateFormat, "y", "06", 1)
-
-	dateFormat = strings.Replace(dateFormat, "SSS", "000", 1)
-
-	dateFormat = strings.Replace(dateFormat, "MMMM", "January", 1)
-	dateFormat = strings.Replace(dateFormat, "MMM", "Jan", 1)
-	dateFormat = strings.Replace(dateFormat, "MM", "01", 1)
-	dateFormat = strings.Replace(dateFormat, "M", "1", 1)
-
-	dateFormat = strings.Replace(dateFormat, "z", "MST", 1)
-	dateFormat = strings.Replace(dateFormat, "zzzz", "Z0700", 1)
-	dateFormat = strings.Replace(dateFormat, "zz:zz", "Z07:00", 1)
-	dateFormat = strings.Replace(dateFormat, "ZZ", "-0700", 1)
-	dateFormat = strings.Replace(dateFormat, "Z", "-07", 1)
+//formatTimeToken returns formatted value of java date format token that go layout can not express, ok is false for other tokens
+func formatTimeToken(t time.Time, letter byte, count int) (value string, ok bool) {
+	switch letter {
+	case 'w':
+		_, week := t.ISOWeek()
+		return fmt.Sprintf("%0*d", count, week), true
+	case 'D':
+		return fmt.Sprintf("%0*d", count, t.YearDay()), true
+	case 'Y':
+		year, _ := t.ISOWeek()
+		if count == 2 {
+			return fmt.Sprintf("%02d", year%100), true
+		}
+		return fmt.Sprintf("%0*d", count, year), true
+	}
+	return "", false
+}
 
-	dateFormat = strings.Replace(dateFormat, "EEEE", "Monday", 1)
-	dateFormat = strings.Replace(dateFormat, "E", "Mon", 1)
+//OrdinalDay returns day of month with english ordinal suffix, i.e. 1st, 2nd, 3rd, 4th, 11th, 22nd
+func OrdinalDay(t time.Time) string {
+	var day = t.Day()
+	var suffix = "th"
+	if day%100 < 11 || day%100 > 13 {
+		switch day % 10 {
+		case 1:
+			suffix = "st"
+		case 2:
+			suffix = "nd"
+		case 3:
+			suffix = "rd"
+		}
+	}
+	return fmt.Sprintf("%d%v", day, suffix)
+}
 
-	return dateFormat
+//FormatTime formats time with java date format, in addition to DateFormatToLayout tokens it supports w (ISO week of year),
+//D (day of year), Y (ISO week based year) and do (ordinal day of month), i.e. "YYYY-ww", "yyyy.DDD" or "MMMM do".
+func FormatTime(t time.Time, dateFormat string) string {
+	var result = make([]byte, 0, len(dateFormat)+8)
+	var segmentStart = 0
+	for i := 0; i < len(dateFormat); {
+		var letter = dateFormat[i]
+		var count = 1
+		for i+count < len(dateFormat) && dateFormat[i+count] == letter {
+			count++
+		}
+		value, ok := formatTimeToken(t, letter, count)
+		if !ok && count == 1 && strings.HasPrefix(dateFormat[i:], "do") {
+			value, ok, count = OrdinalDay(t), true, 2
+		}
+		if ok {
+			if segmentStart < i {
+				result = append(result, t.Format(DateFormatToLayout(dateFormat[segmentStart:i]))...)
+			}
+			result = append(result, value...)
+			segmentStart = i + count
+		}
+		i += count
+	}
+	if segmentStart < len(dateFormat) {
+		result = append(result, t.Format(DateFormatToLayout(dateFormat[segmentStart:]))...)
+	}
+	return string(result)
 }
 
 //GetTimeLayout returns time laout from passed in map, first it check if DateLayoutKeyword is defined is so it returns it, otherwise it check DateFormatKeyword and if exists converts it to  dateLayout
@@ -85,3 +209,52 @@ func TimestampToString(dateFormat string, unixTimestamp, unixNanoTimestamp int64
 	dateLayout := DateFormatToLayout(dateFormat)
 	return t.Format(dateLayout)
 }
+
+//NanoToString formats unix timestamp in nanoseconds to passed in java style date format
+func NanoToString(dateFormat string, nanos int64) string {
+	return time.Unix(0, nanos).Format(DateFormatToLayout(dateFormat))
+}
+
+//MillisToString formats unix timestamp in milliseconds to passed in java style date format
+func MillisToString(dateFormat string, millis int64) string {
+	return time.Unix(millis/1000, (millis%1000)*int64(time.Millisecond)).Format(DateFormatToLayout(dateFormat))
+}
+
+var humanDurationUnits = []struct {
+	name     string
+	duration time.Duration
+}{
+	{"h", time.Hour},
+	{"m", time.Minute},
+	{"s", time.Second},
+	{"ms", time.Millisecond},
+	{"µs", time.Microsecond},
+	{"ns", time.Nanosecond},
+}
+
+//DurationToHumanString formats duration as space separated components i.e. 2h 5m 3s, emitting up to maxUnits largest non zero components (all if maxUnits is less than 1)
+func DurationToHumanString(d time.Duration, maxUnits int) string {
+	if d == 0 {
+		return "0s"
+	}
+	var sign = ""
+	var remaining = uint64(d)
+	if d < 0 {
+		sign = "-"
+		remaining = uint64(-(d + 1)) + 1
+	}
+	var components = make([]string, 0)
+	for _, unit := range humanDurationUnits {
+		if maxUnits > 0 && len(components) >= maxUnits {
+			break
+		}
+		var unitDuration = uint64(unit.duration)
+		if remaining < unitDuration {
+			continue
+		}
+		var value = remaining / unitDuration
+		remaining = remaining % unitDuration
+		components = append(components, fmt.Sprintf("%d%v", value, unit.name))
+	}
+	return sign + strings.Join(components, " ")
+}
@@ -59,12 +59,127 @@ func TestTimeFormat(t *testing.T) {
 
 }
 
+func TestDateFormatToLayout_DayName(t *testing.T) {
+	var date = time.Date(2017, 11, 4, 22, 29, 33, 0, time.UTC)
+	{
+		dateLayout := toolbox.DateFormatToLayout("EEE, dd MMM yyyy HH:mm:ss z")
+		assert.Equal(t, time.RFC1123, dateLayout)
+		assert.Equal(t, "Sat, 04 Nov 2017 22:29:33 UTC", date.Format(dateLayout))
+	}
+	{
+		dateLayout := toolbox.DateFormatToLayout("EEEE dd/MM/yyyy")
+		assert.Equal(t, "Monday 02/01/2006", dateLayout)
+		assert.Equal(t, "Saturday 04/11/2017", date.Format(dateLayout))
+	}
+	{
+		dateLayout := toolbox.DateFormatToLayout("E yyyy-MM-dd")
+		assert.Equal(t, "Sat 2017-11-04", date.Format(dateLayout))
+	}
+}
+
+func TestDateFormatToLayout_MonthName(t *testing.T) {
+	var date = time.Date(2017, 11, 4, 0, 0, 0, 0, time.UTC)
+	var useCases = []struct {
+		format   string
+		layout   string
+		expected string
+	}{
+		{"dd MMM yyyy", "02 Jan 2006", "04 Nov 2017"},
+		{"dd MMMM yyyy", "02 January 2006", "04 November 2017"},
+		{"MMMMdd yyyy", "January02 2006", "November04 2017"},
+		{"yyyy-MM-dd", "2006-01-02", "2017-11-04"},
+		{"yyyy/M/d", "2006/1/2", "2017/11/4"},
+	}
+	for _, useCase := range useCases {
+		dateLayout := toolbox.DateFormatToLayout(useCase.format)
+		assert.Equal(t, useCase.layout, dateLayout, useCase.format)
+		assert.Equal(t, useCase.expected, date.Format(dateLayout), useCase.format)
+		parsed, err := time.Parse(dateLayout, useCase.expected)
+		if assert.Nil(t, err, useCase.format) {
+			assert.Equal(t, date, parsed, useCase.format)
+		}
+	}
+}
+
+func TestDateFormatToLayout_FractionalSeconds(t *testing.T) {
+	var date = time.Date(2017, 11, 4, 22, 29, 33, 123456789, time.UTC)
+	var useCases = []struct {
+		format   string
+		layout   string
+		expected string
+	}{
+		{"HH:mm:ss.S", "15:04:05.0", "22:29:33.1"},
+		{"HH:mm:ss.SSS", "15:04:05.000", "22:29:33.123"},
+		{"HH:mm:ss.SSSSSS", "15:04:05.000000", "22:29:33.123456"},
+		{"HH:mm:ss,SSSSSSSSSSSS", "15:04:05,000000000", "22:29:33,123456789"},
+	}
+	for _, useCase := range useCases {
+		dateLayout := toolbox.DateFormatToLayout(useCase.format)
+		assert.Equal(t, useCase.layout, dateLayout, useCase.format)
+		assert.Equal(t, useCase.expected, date.Format(dateLayout), useCase.format)
+		parsed, err := time.Parse(dateLayout, useCase.expected)
+		if assert.Nil(t, err, useCase.format) {
+			assert.Equal(t, useCase.expected, parsed.Format(dateLayout), useCase.format)
+		}
+	}
+}
+
+func TestDateFormatToLayout_ISOOffset(t *testing.T) {
+	var useCases = []struct {
+		format    string
+		layout    string
+		value     string
+		offset    int
+		formatted string
+	}{
+		{"yyyy-MM-ddTHH:mm:ssXXX", "2006-01-02T15:04:05Z07:00", "2017-11-04T22:29:33+05:30", 5*3600 + 30*60, "2017-11-04T22:29:33+05:30"},
+		{"yyyy-MM-dd HH:mm:ssXXX", "2006-01-02 15:04:05Z07:00", "2017-11-04 22:29:33-08:00", -8 * 3600, "2017-11-04 22:29:33-08:00"},
+		{"yyyy-MM-dd HH:mm:ss XX", "2006-01-02 15:04:05 Z0700", "2017-11-04 22:29:33 +0530", 5*3600 + 30*60, "2017-11-04 22:29:33 +0530"},
+		{"yyyy-MM-dd HH:mm:ss X", "2006-01-02 15:04:05 Z07", "2017-11-04 22:29:33 -03", -3 * 3600, "2017-11-04 22:29:33 -03"},
+		{"yyyy-MM-dd HH:mm:ssXXX", "2006-01-02 15:04:05Z07:00", "2017-11-04 22:29:33+00:00", 0, "2017-11-04 22:29:33Z"},
+		{"yyyy-MM-ddTHH:mm:ssXXX", "2006-01-02T15:04:05Z07:00", "2017-11-04T22:29:33Z", 0, "2017-11-04T22:29:33Z"},
+		{"yyyy-MM-dd HH:mm:ss X", "2006-01-02 15:04:05 Z07", "2017-11-04 22:29:33 Z", 0, "2017-11-04 22:29:33 Z"},
+	}
+	for _, useCase := range useCases {
+		dateLayout := toolbox.DateFormatToLayout(useCase.format)
+		assert.Equal(t, useCase.layout, dateLayout, useCase.format)
+		parsed, err := time.Parse(dateLayout, useCase.value)
+		if assert.Nil(t, err, useCase.value) {
+			_, offset := parsed.Zone()
+			assert.Equal(t, useCase.offset, offset, useCase.value)
+			assert.Equal(t, useCase.formatted, parsed.Format(dateLayout), useCase.value)
+		}
+	}
+	//existing ZZ mapping is unchanged
+	assert.Equal(t, "-0700", toolbox.DateFormatToLayout("ZZ"))
+}
+
+func TestDateFormatToLayout_AmPmMarker(t *testing.T) {
+	{
+		dateLayout := toolbox.DateFormatToLayout("hh:mm a")
+		assert.Equal(t, "03:04 PM", dateLayout)
+		timeValue, err := time.Parse(dateLayout, "02:30 PM")
+		assert.Nil(t, err)
+		assert.Equal(t, 14, timeValue.Hour())
+		assert.Equal(t, "02:30 PM", timeValue.Format(dateLayout))
+	}
+	{
+		dateLayout := toolbox.DateFormatToLayout("yyyy-MM-dd h:mm:ss aa")
+		var date = time.Date(2017, 11, 4, 9, 5, 0, 0, time.UTC)
+		assert.Equal(t, "2017-11-04 9:05:00 AM", date.Format(dateLayout))
+		timeValue, err := time.Parse(dateLayout, "2017-11-04 10:29:33 PM")
+		assert.Nil(t, err)
+		assert.Equal(t, 22, timeValue.Hour())
+		assert.Equal(t, "2017-11-04 10:29:33 PM", timeValue.Format(dateLayout))
+	}
+}
+
 func TestGetTimeLayout(t *testing.T) {
 	{
 		settings := map[string]string{
 			toolbox.DateFormatKeyword: "yyyy-MM-dd HH:mm:ss z",
 		}
-		assert.Equal(t, "2006-1-02 15:04:05 MST", toolbox.GetTimeLayout(settings))
+		assert.Equal(t, "2006-01-02 15:04:05 MST", toolbox.GetTimeLayout(settings))
 		assert.True(t, toolbox.HasTimeLayout(settings))
 	}
 	{
@@ -94,3 +209,83 @@ func TestTimestampToString(t *testing.T) {
 	}
 
 }
+
+func TestNanoToString(t *testing.T) {
+	var instant = time.Date(2016, 11, 29, 16, 9, 3, 722684356, time.UTC).In(time.Local)
+	assert.Equal(t, instant.Format("2006-01-02 15:04:05.000000000"), toolbox.NanoToString("yyyy-MM-dd HH:mm:ss.SSSSSSSSS", 1480435743722684356))
+	assert.Equal(t, time.Unix(0, 0).Format("2006-01-02 15:04:05"), toolbox.NanoToString("yyyy-MM-dd HH:mm:ss", 0))
+	assert.Equal(t, time.Unix(0, -1).Format("2006-01-02 15:04:05.000000000"), toolbox.NanoToString("yyyy-MM-dd HH:mm:ss.SSSSSSSSS", -1))
+}
+
+func TestMillisToString(t *testing.T) {
+	var instant = time.Date(2016, 11, 29, 16, 9, 3, 722000000, time.UTC).In(time.Local)
+	assert.Equal(t, instant.Format("2006-01-02 15:04:05.000"), toolbox.MillisToString("yyyy-MM-dd HH:mm:ss.SSS", 1480435743722))
+	assert.Equal(t, toolbox.NanoToString("yyyy-MM-dd HH:mm:ss.SSS", 1480435743722*int64(time.Millisecond)), toolbox.MillisToString("yyyy-MM-dd HH:mm:ss.SSS", 1480435743722))
+	var beforeEpoch = time.Date(1969, 12, 31, 23, 59, 59, 500000000, time.UTC).In(time.Local)
+	assert.Equal(t, beforeEpoch.Format("2006-01-02 15:04:05.000"), toolbox.MillisToString("yyyy-MM-dd HH:mm:ss.SSS", -500))
+}
+
+func TestFormatTime(t *testing.T) {
+	var useCases = []struct {
+		time     time.Time
+		format   string
+		expected string
+	}{
+		{time.Date(2017, 11, 4, 22, 29, 33, 0, time.UTC), "yyyy-MM-dd HH:mm:ss", "2017-11-04 22:29:33"},
+		{time.Date(2017, 11, 4, 0, 0, 0, 0, time.UTC), "yyyy ww DDD", "2017 44 308"},
+		{time.Date(2017, 2, 1, 0, 0, 0, 0, time.UTC), "yyyy.D w", "2017.32 5"},
+		//2016-01-01 belongs to ISO week 53 of 2015
+		{time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC), "yyyy-MM-dd YYYY-ww DDD", "2016-01-01 2015-53 001"},
+		//2019-12-30 belongs to ISO week 1 of 2020
+		{time.Date(2019, 12, 30, 0, 0, 0, 0, time.UTC), "yyyy-MM-dd YYYY-ww DDD", "2019-12-30 2020-01 364"},
+		{time.Date(2020, 12, 31, 0, 0, 0, 0, time.UTC), "YY/ww DDD", "20/53 366"},
+		{time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC), "EEE YYYY-ww", "Mon 2018-01"},
+	}
+	for _, useCase := range useCases {
+		assert.Equal(t, useCase.expected, toolbox.FormatTime(useCase.time, useCase.format), useCase.format)
+	}
+}
+
+func TestOrdinalDay(t *testing.T) {
+	for day, expected := range map[int]string{
+		1:  "1st",
+		2:  "2nd",
+		3:  "3rd",
+		4:  "4th",
+		11: "11th",
+		12: "12th",
+		13: "13th",
+		21: "21st",
+		22: "22nd",
+		23: "23rd",
+		30: "30th",
+		31: "31st",
+	} {
+		assert.Equal(t, expected, toolbox.OrdinalDay(time.Date(2017, 1, day, 0, 0, 0, 0, time.UTC)))
+	}
+	var date = time.Date(2017, 11, 22, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, "November 22nd, 2017", toolbox.FormatTime(date, "MMMM do, yyyy"))
+	assert.Equal(t, "22 22nd", toolbox.FormatTime(date, "dd do"))
+}
+
+func TestDurationToHumanString(t *testing.T) {
+	var useCases = []struct {
+		duration time.Duration
+		maxUnits int
+		expected string
+	}{
+		{2*time.Hour + 5*time.Minute + 3*time.Second, 3, "2h 5m 3s"},
+		{2*time.Hour + 5*time.Minute + 3*time.Second + 450*time.Millisecond, 2, "2h 5m"},
+		{2*time.Hour + 5*time.Minute + 3*time.Second + 450*time.Millisecond, 0, "2h 5m 3s 450ms"},
+		{2*time.Hour + 3*time.Second, 2, "2h 3s"},
+		{450 * time.Millisecond, 2, "450ms"},
+		{1500 * time.Microsecond, 3, "1ms 500µs"},
+		{42 * time.Nanosecond, 1, "42ns"},
+		{-(90 * time.Second), 2, "-1m 30s"},
+		{-(450 * time.Millisecond), 1, "-450ms"},
+		{0, 2, "0s"},
+	}
+	for _, useCase := range useCases {
+		assert.Equal(t, useCase.expected, toolbox.DurationToHumanString(useCase.duration, useCase.maxUnits), useCase.duration.String())
+	}
+}
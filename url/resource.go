@@ -6,6 +6,7 @@ import (
 	"github.com/viant/toolbox"
 	"github.com/viant/toolbox/cred"
 	"github.com/viant/toolbox/storage"
+	"io"
 	"io/ioutil"
 	"net/url"
 	"os"
@@ -140,6 +141,9 @@ func (r *Resource) Download() ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
+	if closer, ok := reader.(io.Closer); ok {
+		defer closer.Close()
+	}
 	content, err := ioutil.ReadAll(reader)
 	if err != nil {
 		return nil, err
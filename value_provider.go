@@ -2,8 +2,14 @@ package toolbox
 
 import (
 	"fmt"
+	"net"
 	"os"
+	"os/user"
+	"reflect"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -13,45 +19,127 @@ type ValueProvider interface {
 	Get(context Context, arguments ...interface{}) (interface{}, error)
 }
 
-//ValueProviderRegistry registry of value providers
+//ValueProviderRegistry registry of value providers, it is safe for concurrent use
 type ValueProviderRegistry interface {
 	Register(name string, valueProvider ValueProvider)
 
+	//Unregister removes value provider for passed in name if present
+	Unregister(name string)
+
 	Contains(name string) bool
 
 	Names() []string
 
+	//Get returns value provider for passed in name, it panics if provider is not registered, use Lookup to avoid panic
 	Get(name string) ValueProvider
+
+	//Lookup returns value provider for passed in name and true if provider was found
+	Lookup(name string) (ValueProvider, bool)
+
+	//RegisterAlias registers alias name resolving to provider registered under existing name, it returns error if existing name is not registered
+	RegisterAlias(alias, existing string) error
+
+	//Clone returns an independent registry with the same providers and aliases, providers themselves are shared (shallow copy)
+	Clone() ValueProviderRegistry
 }
 
 type valueProviderRegistryImpl struct {
 	registry map[string](ValueProvider)
+	aliases  map[string]string
+	mutex    *sync.RWMutex
 }
 
 func (r valueProviderRegistryImpl) Register(name string, valueProvider ValueProvider) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	delete(r.aliases, name)
 	r.registry[name] = valueProvider
 }
 
+func (r valueProviderRegistryImpl) RegisterAlias(alias, existing string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if target, ok := r.aliases[existing]; ok {
+		existing = target
+	}
+	if _, ok := r.registry[existing]; !ok {
+		return fmt.Errorf("failed to register alias %v: %v is not registered", alias, existing)
+	}
+	if alias == existing {
+		return fmt.Errorf("failed to register alias %v: alias can not refer to itself", alias)
+	}
+	delete(r.registry, alias)
+	r.aliases[alias] = existing
+	return nil
+}
+
+func (r valueProviderRegistryImpl) Unregister(name string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if _, ok := r.aliases[name]; ok {
+		delete(r.aliases, name)
+		return
+	}
+	delete(r.registry, name)
+}
+
 func (r valueProviderRegistryImpl) Contains(name string) bool {
-	_, ok := r.registry[name]
+	_, ok := r.Lookup(name)
 	return ok
 }
 
 func (r valueProviderRegistryImpl) Get(name string) ValueProvider {
-	if result, ok := r.registry[name]; ok {
+	if result, ok := r.Lookup(name); ok {
 		return result
 	}
 	panic(fmt.Sprintf("failed to lookup name: %v", name))
 }
 
+func (r valueProviderRegistryImpl) Lookup(name string) (ValueProvider, bool) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	if target, ok := r.aliases[name]; ok {
+		name = target
+	}
+	result, ok := r.registry[name]
+	return result, ok
+}
+
 func (r valueProviderRegistryImpl) Names() []string {
-	return MapKeysToStringSlice(&r.registry)
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	var result = MapKeysToStringSlice(&r.registry)
+	for alias, target := range r.aliases {
+		if _, ok := r.registry[target]; ok {
+			result = append(result, alias)
+		}
+	}
+	return result
+}
+
+func (r valueProviderRegistryImpl) Clone() ValueProviderRegistry {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	var result = &valueProviderRegistryImpl{
+		registry: make(map[string]ValueProvider, len(r.registry)),
+		aliases:  make(map[string]string, len(r.aliases)),
+		mutex:    &sync.RWMutex{},
+	}
+	for name, provider := range r.registry {
+		result.registry[name] = provider
+	}
+	for alias, target := range r.aliases {
+		result.aliases[alias] = target
+	}
+	return result
 }
 
 //NewValueProviderRegistry create new NewValueProviderRegistry
 func NewValueProviderRegistry() ValueProviderRegistry {
 	var result ValueProviderRegistry = &valueProviderRegistryImpl{
 		registry: make(map[string]ValueProvider),
+		aliases:  make(map[string]string),
+		mutex:    &sync.RWMutex{},
 	}
 	return result
 }
@@ -64,15 +152,79 @@ func (p envValueProvider) Get(context Context, arguments ...interface{}) (interf
 	if found {
 		return value, nil
 	}
+	if len(arguments) > 1 {
+		return arguments[1], nil
+	}
 	return nil, fmt.Errorf("failed to lookup %v in env", key)
 }
 
-//NewEnvValueProvider returns a provider that returns a value of env variables.
+//NewEnvValueProvider returns a provider that returns a value of env variables, optional second argument is returned verbatim (without casting) as default value
+//when variable is not defined, otherwise missing variable is an error.
 func NewEnvValueProvider() ValueProvider {
 	var result ValueProvider = &envValueProvider{}
 	return result
 }
 
+type hostnameProvider struct{}
+
+func (p hostnameProvider) Get(context Context, arguments ...interface{}) (interface{}, error) {
+	hostname, err := os.Hostname()
+	if err != nil {
+		return nil, err
+	}
+	if len(arguments) == 0 || AsString(arguments[0]) != "fqdn" {
+		return hostname, nil
+	}
+	addresses, err := net.LookupHost(hostname)
+	if err != nil {
+		return hostname, nil
+	}
+	for _, address := range addresses {
+		if names, err := net.LookupAddr(address); err == nil && len(names) > 0 {
+			return strings.TrimSuffix(names[0], "."), nil
+		}
+	}
+	return hostname, nil
+}
+
+//NewHostnameProvider returns a provider that returns host name, if the first argument is "fqdn", it attempts to resolve fully qualified domain name
+func NewHostnameProvider() ValueProvider {
+	var result ValueProvider = &hostnameProvider{}
+	return result
+}
+
+type userProvider struct{}
+
+func (p userProvider) Get(context Context, arguments ...interface{}) (interface{}, error) {
+	if len(arguments) != 1 {
+		return nil, fmt.Errorf("expected 1 argument (field) but had %v", len(arguments))
+	}
+	current, err := user.Current()
+	if err != nil {
+		return nil, err
+	}
+	switch field := AsString(arguments[0]); field {
+	case "name":
+		return current.Username, nil
+	case "uid":
+		return current.Uid, nil
+	case "gid":
+		return current.Gid, nil
+	case "home":
+		return current.HomeDir, nil
+	default:
+		return nil, fmt.Errorf("unsupported user field: %v, expected name, uid, gid or home", field)
+	}
+}
+
+//NewUserProvider returns a provider that returns current OS user field (first argument): name, uid, gid or home
+func NewUserProvider() ValueProvider {
+	var result ValueProvider = &userProvider{}
+	return result
+}
+
+const castedSnippetMaxLength = 64
+
 type castedValueProvider struct{}
 
 func (p castedValueProvider) Get(context Context, arguments ...interface{}) (interface{}, error) {
@@ -98,12 +250,34 @@ func (p castedValueProvider) Get(context Context, arguments ...interface{}) (int
 		return AsBoolean(arguments[1]), nil
 	case "string":
 		return AsString(arguments[1]), nil
+	case "duration":
+		var value = AsString(arguments[1])
+		duration, err := time.ParseDuration(value)
+		if err == nil {
+			return duration, nil
+		}
+		seconds, floatErr := strconv.ParseFloat(value, 64)
+		if floatErr != nil {
+			return nil, fmt.Errorf("failed to cast to duration %v due to %v", value, err)
+		}
+		return time.Duration(seconds * float64(time.Second)), nil
+	case "json":
+		var value = AsString(arguments[1])
+		var result interface{}
+		err := NewJSONDecoderFactory().Create(strings.NewReader(value)).Decode(&result)
+		if err != nil {
+			if len(value) > castedSnippetMaxLength {
+				value = value[:castedSnippetMaxLength] + "..."
+			}
+			return nil, fmt.Errorf("failed to cast to json %v due to %v", value, err)
+		}
+		return result, nil
 
 	}
 	return nil, fmt.Errorf("failed to cast to %v - unsupported type", key)
 }
 
-//NewCastedValueProvider return a provider that return casted value type
+//NewCastedValueProvider return a provider that return casted value type, supported types: time, int, float, bool, string, duration and json
 func NewCastedValueProvider() ValueProvider {
 	var result ValueProvider = &castedValueProvider{}
 	return result
@@ -161,9 +335,9 @@ func (p timeDiffProvider) Get(context Context, arguments ...interface{}) (interf
 	resultTime = resultTime.Add(durationDelta)
 	switch format {
 	case "unix":
-		return int(resultTime.Unix()+resultTime.UnixNano()) / 1000000000, nil
+		return int(resultTime.Unix()), nil
 	case "timestamp":
-		return int(resultTime.Unix()+resultTime.UnixNano()) / 1000000, nil
+		return resultTime.Unix()*1000 + int64(resultTime.Nanosecond()/1e6), nil
 
 	default:
 		if len(format) > 0 {
@@ -174,7 +348,7 @@ func (p timeDiffProvider) Get(context Context, arguments ...interface{}) (interf
 }
 
 //NewTimeDiffProvider returns a provider that delta, time unit  and optionally format
-//format as java date format or unix or timestamp
+//format as java date format or unix (seconds) or timestamp (milliseconds), negative delta moves time to the past
 func NewTimeDiffProvider() ValueProvider {
 	var result ValueProvider = &timeDiffProvider{}
 	return result
@@ -183,10 +357,26 @@ func NewTimeDiffProvider() ValueProvider {
 type weekdayProvider struct{}
 
 func (p weekdayProvider) Get(context Context, arguments ...interface{}) (interface{}, error) {
-	var now = time.Now()
-	return int(now.Weekday()), nil
+	var date = time.Now()
+	if len(arguments) > 0 && arguments[0] != nil {
+		var layout = ""
+		if len(arguments) > 1 {
+			layout = AsString(arguments[1])
+		}
+		timeValue := AsTime(arguments[0], layout)
+		if timeValue == nil {
+			return nil, fmt.Errorf("failed to convert %v to time", arguments[0])
+		}
+		date = *timeValue
+	}
+	if len(arguments) > 2 && AsString(arguments[2]) == "name" {
+		return date.Weekday().String(), nil
+	}
+	return int(date.Weekday()), nil
 }
 
+//NewWeekdayProvider returns a provider that returns weekday index (0 - Sunday) of now or optional date (first argument) with optional date layout (second argument),
+//if the third argument is "name", weekday name is returned instead.
 func NewWeekdayProvider() ValueProvider {
 	return &weekdayProvider{}
 }
@@ -206,10 +396,23 @@ func NewNilValueProvider() ValueProvider {
 type currentDateProvider struct{}
 
 func (p currentDateProvider) Get(context Context, arguments ...interface{}) (interface{}, error) {
-	return time.Now().Local().Format("20060102"), nil
+	var layout = "20060102"
+	if len(arguments) > 0 && AsString(arguments[0]) != "" {
+		layout = DateFormatToLayout(AsString(arguments[0]))
+	}
+	var now = time.Now().Local()
+	if len(arguments) > 1 {
+		location, err := time.LoadLocation(AsString(arguments[1]))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load timezone %v: %v", arguments[1], err)
+		}
+		now = now.In(location)
+	}
+	return now.Format(layout), nil
 }
 
-//NewCurrentDateProvider returns a provider that returns current date in the format yyyymmdd, i.e. 20170205
+//NewCurrentDateProvider returns a provider that returns current date in the format yyyymmdd, i.e. 20170205,
+//optional first argument specifies java style date format, optional second argument specifies timezone name, i.e. UTC
 func NewCurrentDateProvider() ValueProvider {
 	var result ValueProvider = &currentDateProvider{}
 	return result
@@ -239,6 +442,40 @@ func (d *MapDictionary) Exists(name string) bool {
 	return found
 }
 
+//CaseInsensitiveDictionary represents a dictionary with case insensitive keys
+type CaseInsensitiveDictionary struct {
+	values map[string]interface{}
+}
+
+//Get returns value for passed in key regardless of its case or error
+func (d *CaseInsensitiveDictionary) Get(name string) (interface{}, error) {
+	if result, found := d.values[strings.ToLower(name)]; found {
+		return result, nil
+	}
+	return nil, fmt.Errorf("failed to lookup: %v", name)
+}
+
+//Exists checks if key exists regardless of its case
+func (d *CaseInsensitiveDictionary) Exists(name string) bool {
+	_, found := d.values[strings.ToLower(name)]
+	return found
+}
+
+//NewCaseInsensitiveDictionary creates a case insensitive dictionary for supplied source map, values are kept as is.
+//When source keys differ only by case, keys are applied in sorted order thus value of the last key in sort order wins (i.e. "region" over "Region").
+func NewCaseInsensitiveDictionary(source map[string]interface{}) Dictionary {
+	var keys = make([]string, 0, len(source))
+	for key := range source {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var result = &CaseInsensitiveDictionary{values: make(map[string]interface{}, len(source))}
+	for _, key := range keys {
+		result.values[strings.ToLower(key)] = source[key]
+	}
+	return result
+}
+
 type dictionaryProvider struct {
 	dictionaryContentKey interface{}
 }
@@ -250,13 +487,73 @@ func (p dictionaryProvider) Get(context Context, arguments ...interface{}) (inte
 	var key = AsString(arguments[0])
 	var dictionary Dictionary
 	context.GetInto(p.dictionaryContentKey, &dictionary)
-	if len(arguments) == 1 && !dictionary.Exists(key) {
+	if !dictionary.Exists(key) {
+		if len(arguments) > 1 {
+			return arguments[1], nil
+		}
 		return nil, nil
 	}
 	return dictionary.Get(key)
 }
 
-//NewDictionaryProvider creates a new Dictionary provider, it takes a key context that is a MapDictionary pointer
+//NewDictionaryProvider creates a new Dictionary provider, it takes a key context that is a MapDictionary pointer,
+//first argument is a dictionary key, optional second argument is a default value returned if key does not exist
 func NewDictionaryProvider(contextKey interface{}) ValueProvider {
 	return &dictionaryProvider{contextKey}
 }
+
+type contextValueProvider struct{}
+
+func (p contextValueProvider) Get(context Context, arguments ...interface{}) (interface{}, error) {
+	if len(arguments) == 0 {
+		return nil, fmt.Errorf("expected at least one argument but had 0")
+	}
+	var result interface{}
+	if context != nil && context.GetInto(arguments[0], &result) {
+		return result, nil
+	}
+	if len(arguments) > 1 {
+		return arguments[1], nil
+	}
+	var key = fmt.Sprintf("%T", arguments[0])
+	if keyType, ok := arguments[0].(reflect.Type); ok {
+		key = keyType.String()
+	}
+	return nil, fmt.Errorf("failed to lookup context value for key: %v", key)
+}
+
+//NewContextValueProvider returns a provider that returns context value stored under the first argument key (i.e. (*MapDictionary)(nil) or reflect.Type),
+//missing value is an error unless default value is supplied as the second argument.
+func NewContextValueProvider() ValueProvider {
+	return &contextValueProvider{}
+}
+
+type layeredDictionaryProvider struct {
+	dictionaryContentKeys []interface{}
+}
+
+func (p layeredDictionaryProvider) Get(context Context, arguments ...interface{}) (interface{}, error) {
+	if len(arguments) == 0 {
+		return nil, fmt.Errorf("Expected at least one argument but had 0")
+	}
+	var key = AsString(arguments[0])
+	for _, contextKey := range p.dictionaryContentKeys {
+		var dictionary Dictionary
+		if !context.GetInto(contextKey, &dictionary) || dictionary == nil {
+			continue
+		}
+		if dictionary.Exists(key) {
+			return dictionary.Get(key)
+		}
+	}
+	if len(arguments) == 1 {
+		return nil, nil
+	}
+	return nil, fmt.Errorf("failed to lookup: %v", key)
+}
+
+//NewLayeredDictionaryProvider creates a new Dictionary provider, it takes context keys in priority order, each key is a MapDictionary pointer,
+//the first dictionary that contains a lookup key is used.
+func NewLayeredDictionaryProvider(contextKeys ...interface{}) ValueProvider {
+	return &layeredDictionaryProvider{contextKeys}
+}
@@ -1,6 +1,12 @@
 package toolbox_test
 
 import (
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -18,9 +24,54 @@ func TestNewEnvValueProvider(t *testing.T) {
 		_, err := provider.Get(nil, "_blahblah")
 		assert.NotNil(t, err)
 	}
+	{
+		os.Setenv("_TOOLBOX_PORT", "9090")
+		defer os.Unsetenv("_TOOLBOX_PORT")
+		value, err := provider.Get(nil, "_TOOLBOX_PORT", "8080")
+		assert.Nil(t, err)
+		assert.Equal(t, "9090", value)
+	}
+	{
+		value, err := provider.Get(nil, "_blahblah", "8080")
+		assert.Nil(t, err)
+		assert.Equal(t, "8080", value)
+	}
 
 }
 
+func TestNewHostnameProvider(t *testing.T) {
+	provider := toolbox.NewHostnameProvider()
+	hostname, _ := os.Hostname()
+	{
+		value, err := provider.Get(nil)
+		assert.Nil(t, err)
+		assert.NotEmpty(t, value)
+		assert.Equal(t, hostname, value)
+	}
+	{
+		value, err := provider.Get(nil, "fqdn")
+		assert.Nil(t, err)
+		assert.NotEmpty(t, value)
+	}
+}
+
+func TestNewUserProvider(t *testing.T) {
+	provider := toolbox.NewUserProvider()
+	for _, field := range []string{"name", "uid", "gid", "home"} {
+		value, err := provider.Get(nil, field)
+		assert.Nil(t, err, field)
+		assert.NotEmpty(t, value, field)
+	}
+	{
+		_, err := provider.Get(nil, "shell")
+		assert.NotNil(t, err)
+	}
+	{
+		_, err := provider.Get(nil)
+		assert.NotNil(t, err)
+	}
+}
+
 func TestNewCastedValueProvider(t *testing.T) {
 	provider := toolbox.NewCastedValueProvider()
 
@@ -69,6 +120,45 @@ func TestNewCastedValueProvider(t *testing.T) {
 		assert.NotNil(t, err, "to many parameters")
 	}
 
+	for source, expected := range map[interface{}]time.Duration{
+		"1500ms": 1500 * time.Millisecond,
+		"2h45m":  2*time.Hour + 45*time.Minute,
+		"10s":    10 * time.Second,
+		"3":      3 * time.Second,
+		1.5:      1500 * time.Millisecond,
+		60:       time.Minute,
+	} {
+		value, err := provider.Get(nil, "duration", source)
+		assert.Nil(t, err)
+		assert.Equal(t, expected, value)
+	}
+
+	{
+		_, err := provider.Get(nil, "duration", "abc")
+		assert.NotNil(t, err, "invalid duration")
+	}
+
+	{
+		value, err := provider.Get(nil, "json", `{"id":1, "tags":["a", "b"], "active":true}`)
+		assert.Nil(t, err)
+		assert.EqualValues(t, map[string]interface{}{"id": 1.0, "tags": []interface{}{"a", "b"}, "active": true}, value)
+		assert.Equal(t, 1, toolbox.AsInt(toolbox.AsMap(value)["id"]))
+	}
+
+	{
+		value, err := provider.Get(nil, "json", []byte(`[1, "2", {"k":null}]`))
+		assert.Nil(t, err)
+		assert.EqualValues(t, []interface{}{1.0, "2", map[string]interface{}{"k": nil}}, value)
+	}
+
+	{
+		_, err := provider.Get(nil, "json", `{"id":1, "description":"`+strings.Repeat("x", 100))
+		if assert.NotNil(t, err, "malformed json") {
+			assert.True(t, strings.Contains(err.Error(), `{"id":1`))
+			assert.False(t, strings.Contains(err.Error(), strings.Repeat("x", 100)))
+		}
+	}
+
 	{
 		_, err := provider.Get(nil, "ABC", "1")
 		assert.NotNil(t, err, "NOT IMPLEMENTED")
@@ -81,6 +171,25 @@ func TestNewWeekdayProvider(t *testing.T) {
 	assert.Nil(t, err)
 	assert.NotNil(t, value)
 	assert.Equal(t, toolbox.AsInt(value), int(time.Now().Weekday()))
+	{
+		value, err := provider.Get(nil)
+		assert.Nil(t, err)
+		assert.Equal(t, int(time.Now().Weekday()), value)
+	}
+	{
+		value, err := provider.Get(nil, "2017-11-04", "2006-01-02")
+		assert.Nil(t, err)
+		assert.Equal(t, 6, value)
+	}
+	{
+		value, err := provider.Get(nil, time.Date(2017, 11, 6, 0, 0, 0, 0, time.UTC), "", "name")
+		assert.Nil(t, err)
+		assert.Equal(t, "Monday", value)
+	}
+	{
+		_, err := provider.Get(nil, "04/11/2017", "2006-01-02")
+		assert.NotNil(t, err)
+	}
 }
 
 func TestNewCurrentTimeProvider(t *testing.T) {
@@ -95,6 +204,21 @@ func TestNewCurrentDateProvider(t *testing.T) {
 	value, err := provider.Get(nil)
 	assert.Nil(t, err)
 	assert.NotNil(t, value)
+	assert.Equal(t, time.Now().Local().Format("20060102"), value)
+	{
+		value, err := provider.Get(nil, "yyyy-MM-dd", "UTC")
+		assert.Nil(t, err)
+		assert.Equal(t, time.Now().UTC().Format("2006-01-02"), value)
+	}
+	{
+		value, err := provider.Get(nil, "yyyy")
+		assert.Nil(t, err)
+		assert.Equal(t, time.Now().Local().Format("2006"), value)
+	}
+	{
+		_, err := provider.Get(nil, "yyyy-MM-dd", "Invalid/Timezone")
+		assert.NotNil(t, err)
+	}
 }
 
 func TestNewNilProvider(t *testing.T) {
@@ -112,6 +236,107 @@ func TestNewValueProviderRegistry(t *testing.T) {
 	provider := registry.Get("a")
 	assert.NotNil(t, provider)
 	assert.Equal(t, 1, len(registry.Names()))
+	{
+		provider, ok := registry.Lookup("a")
+		assert.True(t, ok)
+		assert.NotNil(t, provider)
+	}
+	{
+		provider, ok := registry.Lookup("b")
+		assert.False(t, ok)
+		assert.Nil(t, provider)
+		assert.Panics(t, func() {
+			registry.Get("b")
+		})
+	}
+	registry.Unregister("a")
+	assert.False(t, registry.Contains("a"))
+	assert.Equal(t, 0, len(registry.Names()))
+	registry.Unregister("a")
+	assert.False(t, registry.Contains("a"))
+}
+
+func TestValueProviderRegistry_RegisterAlias(t *testing.T) {
+	registry := toolbox.NewValueProviderRegistry()
+	registry.Register("env", toolbox.NewEnvValueProvider())
+
+	err := registry.RegisterAlias("environment", "env")
+	assert.Nil(t, err)
+	assert.True(t, registry.Contains("environment"))
+	assert.Equal(t, registry.Get("env"), registry.Get("environment"))
+	names := registry.Names()
+	sort.Strings(names)
+	assert.Equal(t, []string{"env", "environment"}, names)
+
+	{ //alias of alias resolves to the original provider
+		err := registry.RegisterAlias("e", "environment")
+		assert.Nil(t, err)
+		assert.Equal(t, registry.Get("env"), registry.Get("e"))
+	}
+	{ //alias follows re-registered provider
+		var nilProvider = toolbox.NewNilValueProvider()
+		registry.Register("env", nilProvider)
+		assert.Equal(t, nilProvider, registry.Get("environment"))
+	}
+	{ //missing target
+		err := registry.RegisterAlias("missing", "unknown")
+		assert.NotNil(t, err)
+		assert.False(t, registry.Contains("missing"))
+	}
+	{ //unregistering alias keeps the original provider
+		registry.Unregister("environment")
+		assert.False(t, registry.Contains("environment"))
+		assert.True(t, registry.Contains("env"))
+	}
+	{ //unregistering the original provider disables its aliases
+		registry.Unregister("env")
+		assert.False(t, registry.Contains("e"))
+		assert.Equal(t, 0, len(registry.Names()))
+	}
+}
+
+func TestValueProviderRegistry_Clone(t *testing.T) {
+	registry := toolbox.NewValueProviderRegistry()
+	registry.Register("env", toolbox.NewEnvValueProvider())
+	registry.Register("nil", toolbox.NewNilValueProvider())
+	assert.Nil(t, registry.RegisterAlias("environment", "env"))
+
+	clone := registry.Clone()
+	assert.Equal(t, registry.Get("env"), clone.Get("env"))
+	assert.True(t, clone.Contains("environment"))
+
+	clone.Register("date", toolbox.NewCurrentDateProvider())
+	clone.Register("env", toolbox.NewNilValueProvider())
+	clone.Unregister("nil")
+	assert.Nil(t, clone.RegisterAlias("none", "env"))
+
+	assert.False(t, registry.Contains("date"))
+	assert.False(t, registry.Contains("none"))
+	assert.True(t, registry.Contains("nil"))
+	assert.Equal(t, toolbox.NewEnvValueProvider(), registry.Get("environment"))
+	assert.Equal(t, 3, len(registry.Names()))
+	assert.Equal(t, 4, len(clone.Names()))
+}
+
+func TestValueProviderRegistry_Concurrency(t *testing.T) {
+	registry := toolbox.NewValueProviderRegistry()
+	var waitGroup sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		waitGroup.Add(2)
+		go func(i int) {
+			defer waitGroup.Done()
+			registry.Register(fmt.Sprintf("p%v", i), toolbox.NewNilValueProvider())
+		}(i)
+		go func(i int) {
+			defer waitGroup.Done()
+			if registry.Contains(fmt.Sprintf("p%v", i)) {
+				assert.NotNil(t, registry.Get(fmt.Sprintf("p%v", i)))
+			}
+			registry.Names()
+		}(i)
+	}
+	waitGroup.Wait()
+	assert.Equal(t, 10, len(registry.Names()))
 }
 
 func TestNewDictionaryProviderRegistry(t *testing.T) {
@@ -140,12 +365,65 @@ func TestNewDictionaryProviderRegistry(t *testing.T) {
 	}
 
 	{
-		value, err := provider.Get(context, "k13", "true")
-		assert.NotNil(t, err)
+		value, err := provider.Get(context, "k13")
+		assert.Nil(t, err)
+		assert.Nil(t, value)
+	}
+	{
+		value, err := provider.Get(context, "region", "us-east-1")
+		assert.Nil(t, err)
+		assert.Equal(t, "us-east-1", value)
+	}
+	{
+		value, err := provider.Get(context, "k1", "default")
+		assert.Nil(t, err)
+		assert.Equal(t, "123", value)
+	}
+	{
+		dictionary["k3"] = nil
+		value, err := provider.Get(context, "k3", "default")
+		assert.Nil(t, err)
 		assert.Nil(t, value)
+	}
+
+}
 
+func TestNewCaseInsensitiveDictionary(t *testing.T) {
+	var source = map[string]interface{}{
+		"Region":    "us-west-1",
+		"region":    "us-east-1",
+		"AppName":   "toolbox",
+		"MAX_RETRY": 3,
 	}
+	dictionary := toolbox.NewCaseInsensitiveDictionary(source)
+	for _, key := range []string{"appname", "APPNAME", "AppName", "appName"} {
+		assert.True(t, dictionary.Exists(key), key)
+		value, err := dictionary.Get(key)
+		assert.Nil(t, err, key)
+		assert.Equal(t, "toolbox", value, key)
+	}
+	value, err := dictionary.Get("max_retry")
+	assert.Nil(t, err)
+	assert.Equal(t, 3, value)
+
+	value, err = dictionary.Get("REGION")
+	assert.Nil(t, err)
+	assert.Equal(t, "us-east-1", value)
+
+	assert.False(t, dictionary.Exists("missing"))
+	_, err = dictionary.Get("missing")
+	assert.NotNil(t, err)
 
+	{ //dictionary provider integration
+		var key *toolbox.CaseInsensitiveDictionary
+		context := toolbox.NewContext()
+		err = context.Put(key, dictionary)
+		assert.Nil(t, err)
+		provider := toolbox.NewDictionaryProvider(key)
+		value, err := provider.Get(context, "appNAME")
+		assert.Nil(t, err)
+		assert.Equal(t, "toolbox", value)
+	}
 }
 
 func Test_NewNewTimeProvider(t *testing.T) {
@@ -168,9 +446,10 @@ func Test_NewNewTimeProvider(t *testing.T) {
 		result, err := provider.Get(nil, "now", 1, "hour", "timestamp")
 		assert.Nil(t, err)
 
-		var timeResult = toolbox.AsInt(result)
-		in59Mins := int(now.Add(59*time.Minute).Unix() * 1000)
-		in61Mins := int(now.Add(61*time.Minute).Unix() * 1000)
+		timeResult, ok := result.(int64)
+		assert.True(t, ok)
+		in59Mins := now.Add(59*time.Minute).Unix() * 1000
+		in61Mins := now.Add(61*time.Minute).Unix() * 1000
 		assert.True(t, in59Mins < timeResult)
 		assert.True(t, timeResult < in61Mins)
 	}
@@ -188,11 +467,135 @@ func Test_NewNewTimeProvider(t *testing.T) {
 	{
 		result, err := provider.Get(nil, "now", -1, "hour", "h")
 		assert.Nil(t, err)
-		assert.Equal(t, time.Now().Hour()-1, toolbox.AsInt(result))
+		assert.Equal(t, time.Now().Add(-time.Hour).Hour()%12, toolbox.AsInt(result)%12)
 	}
 	{
 		result, err := provider.Get(nil, "now", 1, "hour", "h")
 		assert.Nil(t, err)
-		assert.Equal(t, time.Now().Hour()+1, toolbox.AsInt(result))
+		assert.Equal(t, time.Now().Add(time.Hour).Hour()%12, toolbox.AsInt(result)%12)
+	}
+	{
+		result, err := provider.Get(nil, "now", -3, "day")
+		assert.Nil(t, err)
+
+		var timeResult = toolbox.AsTime(result, "")
+		assert.True(t, timeResult.After(now.Add(-73*time.Hour)))
+		assert.True(t, timeResult.Before(now.Add(-71*time.Hour)))
+	}
+	{
+		var instant = time.Date(2017, 11, 4, 22, 29, 33, 363000000, time.UTC)
+		result, err := provider.Get(nil, instant, -2, "hour", "unix")
+		assert.Nil(t, err)
+		assert.Equal(t, 1509834573-2*3600, result)
+
+		result, err = provider.Get(nil, instant, 30, "sec", "timestamp")
+		assert.Nil(t, err)
+		assert.Equal(t, int64(1509834603363), result)
+
+		var distant = time.Date(2300, 1, 1, 0, 0, 0, 123000000, time.UTC)
+		result, err = provider.Get(nil, distant, 1, "sec", "timestamp")
+		assert.Nil(t, err)
+		assert.Equal(t, int64(10413792001123), result)
+
+		result, err = provider.Get(nil, instant, -1, "week", "unix")
+		assert.Nil(t, err)
+		assert.Equal(t, 1509834573-7*24*3600, result)
+	}
+}
+
+type defaultsDictionary struct {
+	toolbox.MapDictionary
+}
+
+type overridesDictionary struct {
+	toolbox.MapDictionary
+}
+
+func TestNewLayeredDictionaryProvider(t *testing.T) {
+	var defaults = &defaultsDictionary{toolbox.MapDictionary{"k1": "default1", "k2": "default2"}}
+	var env toolbox.MapDictionary = map[string]interface{}{"k2": "env2", "k3": "env3"}
+	var overrides = &overridesDictionary{toolbox.MapDictionary{"k3": "override3"}}
+
+	var defaultsKey *defaultsDictionary
+	var envKey *toolbox.MapDictionary
+	var overridesKey *overridesDictionary
+
+	context := toolbox.NewContext()
+	context.Put(defaultsKey, defaults)
+	context.Put(envKey, &env)
+	context.Put(overridesKey, overrides)
+
+	provider := toolbox.NewLayeredDictionaryProvider(overridesKey, envKey, defaultsKey)
+	for key, expected := range map[string]interface{}{
+		"k1": "default1",
+		"k2": "env2",
+		"k3": "override3",
+	} {
+		value, err := provider.Get(context, key)
+		assert.Nil(t, err)
+		assert.Equal(t, expected, value, key)
+	}
+	{
+		value, err := provider.Get(context, "k4")
+		assert.Nil(t, err)
+		assert.Nil(t, value)
+	}
+	{
+		value, err := provider.Get(context, "k4", "true")
+		assert.NotNil(t, err)
+		assert.Nil(t, value)
+	}
+	{
+		provider := toolbox.NewLayeredDictionaryProvider(defaultsKey, overridesKey)
+		value, err := provider.Get(context, "k3")
+		assert.Nil(t, err)
+		assert.Equal(t, "override3", value)
+	}
+}
+
+type contextValueTestState struct {
+	Counter int
+}
+
+func TestNewContextValueProvider(t *testing.T) {
+	provider := toolbox.NewContextValueProvider()
+	context := toolbox.NewContext()
+
+	var state = &contextValueTestState{Counter: 3}
+	var dictionary = &toolbox.MapDictionary{"k1": "v1"}
+	var started = time.Unix(1500000000, 0)
+	assert.Nil(t, context.Put((*contextValueTestState)(nil), state))
+	assert.Nil(t, context.Put((*toolbox.MapDictionary)(nil), dictionary))
+	assert.Nil(t, context.Put(reflect.TypeOf(started), started))
+
+	{
+		value, err := provider.Get(context, (*contextValueTestState)(nil))
+		assert.Nil(t, err)
+		assert.Equal(t, state, value)
+	}
+	{
+		value, err := provider.Get(context, (*toolbox.MapDictionary)(nil))
+		assert.Nil(t, err)
+		assert.Equal(t, dictionary, value)
+	}
+	{
+		value, err := provider.Get(context, reflect.TypeOf(time.Time{}))
+		assert.Nil(t, err)
+		assert.Equal(t, started, value)
+	}
+	{
+		_, err := provider.Get(context, (*int)(nil))
+		if assert.NotNil(t, err) {
+			assert.Contains(t, err.Error(), "*int")
+		}
+		value, err := provider.Get(context, (*int)(nil), 10)
+		assert.Nil(t, err)
+		assert.Equal(t, 10, value)
+	}
+	{
+		_, err := provider.Get(nil, (*int)(nil))
+		assert.NotNil(t, err)
+		_, err = provider.Get(context)
+		assert.NotNil(t, err)
 	}
 }
@@ -0,0 +1,78 @@
+package toolbox
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+type varProvider struct {
+	contextKey interface{}
+}
+
+//lookup navigates nested maps and slices with dotted path, it returns false if any path segment is missing
+func (p varProvider) lookup(source interface{}, path string) (interface{}, bool) {
+	var result = source
+	for _, segment := range strings.Split(path, ".") {
+		if result == nil {
+			return nil, false
+		}
+		var value = reflect.ValueOf(result)
+		for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+			if value.IsNil() {
+				return nil, false
+			}
+			value = value.Elem()
+		}
+		switch value.Kind() {
+		case reflect.Map:
+			var found = false
+			for _, key := range value.MapKeys() {
+				if AsString(key.Interface()) == segment {
+					result = value.MapIndex(key).Interface()
+					found = true
+					break
+				}
+			}
+			if !found {
+				return nil, false
+			}
+		case reflect.Slice:
+			index, err := ToInt(segment)
+			if err != nil || index < 0 || index >= value.Len() {
+				return nil, false
+			}
+			result = value.Index(index).Interface()
+		default:
+			return nil, false
+		}
+	}
+	return result, true
+}
+
+func (p varProvider) Get(context Context, arguments ...interface{}) (interface{}, error) {
+	if len(arguments) == 0 {
+		return nil, fmt.Errorf("expected at least one argument but had 0")
+	}
+	var path = AsString(arguments[0])
+	var source interface{}
+	if context != nil {
+		source = context.GetOptional(p.contextKey)
+	}
+	if value, found := p.lookup(source, path); found {
+		return value, nil
+	}
+	if len(arguments) > 2 && AsBoolean(arguments[2]) {
+		return nil, fmt.Errorf("failed to lookup required variable: %v", path)
+	}
+	if len(arguments) > 1 {
+		return arguments[1], nil
+	}
+	return nil, nil
+}
+
+//NewVarProvider creates a new variable provider, it takes a context key of nested maps/slices structure (i.e. MapDictionary pointer),
+//Get(context, "path.to.key", fallback) returns fallback when any path segment is missing, with third argument set to true missing variable is an error.
+func NewVarProvider(contextKey interface{}) ValueProvider {
+	return &varProvider{contextKey: contextKey}
+}
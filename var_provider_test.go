@@ -0,0 +1,57 @@
+package toolbox_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/toolbox"
+)
+
+func TestNewVarProvider(t *testing.T) {
+	var dictionary toolbox.MapDictionary = map[string]interface{}{
+		"app": map[string]interface{}{
+			"name": "toolbox",
+			"db": map[interface{}]interface{}{
+				"port": 3306,
+			},
+			"hosts": []interface{}{"h1", map[string]interface{}{"name": "h2"}},
+		},
+		"empty": nil,
+	}
+	var key *toolbox.MapDictionary
+	context := toolbox.NewContext()
+	context.Put(key, &dictionary)
+	provider := toolbox.NewVarProvider(key)
+
+	var useCases = []struct {
+		description string
+		arguments   []interface{}
+		expected    interface{}
+	}{
+		{"top level", []interface{}{"app.name"}, "toolbox"},
+		{"nested map", []interface{}{"app.db.port", 1}, 3306},
+		{"slice index", []interface{}{"app.hosts.0"}, "h1"},
+		{"map in slice", []interface{}{"app.hosts.1.name"}, "h2"},
+		{"missing with fallback", []interface{}{"app.db.user", "root"}, "root"},
+		{"missing slice index", []interface{}{"app.hosts.5", "none"}, "none"},
+		{"nil segment", []interface{}{"empty.x", "fallback"}, "fallback"},
+		{"scalar segment", []interface{}{"app.name.first", "fallback"}, "fallback"},
+		{"missing without fallback", []interface{}{"app.x"}, nil},
+		{"required present", []interface{}{"app.name", nil, true}, "toolbox"},
+	}
+	for _, useCase := range useCases {
+		value, err := provider.Get(context, useCase.arguments...)
+		if assert.Nil(t, err, useCase.description) {
+			assert.Equal(t, useCase.expected, value, useCase.description)
+		}
+	}
+	{
+		_, err := provider.Get(context, "app.db.user", nil, true)
+		assert.NotNil(t, err)
+	}
+	{
+		value, err := provider.Get(toolbox.NewContext(), "app.name", "fallback")
+		assert.Nil(t, err)
+		assert.Equal(t, "fallback", value)
+	}
+}
@@ -0,0 +1,70 @@
+package toolbox
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+)
+
+var dailyWindowExpression = regexp.MustCompile(`^([01]?[0-9]|2[0-3]):([0-5][0-9])$`)
+
+type windowProvider struct{}
+
+//minuteOfDay returns minute of the day for HH:mm expression or -1 if expression is not valid
+func (p windowProvider) minuteOfDay(expression string) int {
+	var matched = dailyWindowExpression.FindStringSubmatch(expression)
+	if len(matched) != 3 {
+		return -1
+	}
+	return AsInt(matched[1])*60 + AsInt(matched[2])
+}
+
+func (p windowProvider) asTime(value interface{}) (*time.Time, error) {
+	if result := AsTime(value, ""); result != nil {
+		return result, nil
+	}
+	return nil, fmt.Errorf("failed to convert %v to time", value)
+}
+
+func (p windowProvider) Get(context Context, arguments ...interface{}) (interface{}, error) {
+	if len(arguments) < 2 {
+		return nil, fmt.Errorf("expected at least 2 arguments (start, end) but had: %v", len(arguments))
+	}
+	var reference = time.Now()
+	if len(arguments) > 2 {
+		referenceTime, err := p.asTime(arguments[2])
+		if err != nil {
+			return nil, fmt.Errorf("invalid reference time: %v", err)
+		}
+		reference = *referenceTime
+	}
+	if _, isTime := arguments[0].(time.Time); !isTime {
+		startMinute, endMinute := p.minuteOfDay(AsString(arguments[0])), p.minuteOfDay(AsString(arguments[1]))
+		if startMinute != -1 && endMinute != -1 {
+			var referenceMinute = reference.Hour()*60 + reference.Minute()
+			if startMinute <= endMinute {
+				return startMinute <= referenceMinute && referenceMinute <= endMinute, nil
+			}
+			return referenceMinute >= startMinute || referenceMinute <= endMinute, nil
+		}
+	}
+	start, err := p.asTime(arguments[0])
+	if err != nil {
+		return nil, fmt.Errorf("invalid window start: %v", err)
+	}
+	end, err := p.asTime(arguments[1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid window end: %v", err)
+	}
+	if end.Before(*start) {
+		return nil, fmt.Errorf("invalid window: end %v is before start %v", end, start)
+	}
+	return !reference.Before(*start) && !reference.After(*end), nil
+}
+
+//NewWindowProvider returns a provider that returns true if now or optional reference time (third argument) is within [start, end] window,
+//start and end can be time or HH:mm daily window expressions, daily window wraps past midnight when start is after end.
+func NewWindowProvider() ValueProvider {
+	var result ValueProvider = &windowProvider{}
+	return result
+}
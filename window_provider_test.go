@@ -0,0 +1,53 @@
+package toolbox_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/toolbox"
+)
+
+func TestNewWindowProvider(t *testing.T) {
+	provider := toolbox.NewWindowProvider()
+
+	var useCases = []struct {
+		description string
+		start       interface{}
+		end         interface{}
+		reference   interface{}
+		expected    bool
+	}{
+		{"daily window", "09:00", "17:30", time.Date(2017, 1, 1, 12, 0, 0, 0, time.Local), true},
+		{"daily window end", "09:00", "17:30", time.Date(2017, 1, 1, 17, 30, 0, 0, time.Local), true},
+		{"outside daily window", "09:00", "17:30", time.Date(2017, 1, 1, 18, 0, 0, 0, time.Local), false},
+		{"wrapped daily window before midnight", "22:00", "2:00", time.Date(2017, 1, 1, 23, 15, 0, 0, time.Local), true},
+		{"wrapped daily window after midnight", "22:00", "2:00", time.Date(2017, 1, 1, 1, 15, 0, 0, time.Local), true},
+		{"outside wrapped daily window", "22:00", "2:00", time.Date(2017, 1, 1, 12, 0, 0, 0, time.Local), false},
+		{"absolute window", "2017-01-01 00:00:00", "2017-01-31 00:00:00", "2017-01-15 10:00:00", true},
+		{"outside absolute window", time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2017, 1, 2, 0, 0, 0, 0, time.UTC), time.Date(2017, 1, 3, 0, 0, 0, 0, time.UTC), false},
+	}
+	for _, useCase := range useCases {
+		value, err := provider.Get(nil, useCase.start, useCase.end, useCase.reference)
+		if assert.Nil(t, err, useCase.description) {
+			assert.Equal(t, useCase.expected, value, useCase.description)
+		}
+	}
+	{
+		value, err := provider.Get(nil, "00:00", "23:59")
+		assert.Nil(t, err)
+		assert.Equal(t, true, value)
+	}
+	{
+		_, err := provider.Get(nil, "2017-01-31 00:00:00", "2017-01-01 00:00:00")
+		assert.NotNil(t, err)
+	}
+	{
+		_, err := provider.Get(nil, "abc", "2017-01-01 00:00:00")
+		assert.NotNil(t, err)
+	}
+	{
+		_, err := provider.Get(nil, "09:00")
+		assert.NotNil(t, err)
+	}
+}